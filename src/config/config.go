@@ -0,0 +1,177 @@
+// Package config reads the settings of the command line tool from a TOML
+// file. Only the part of TOML the settings need is supported: top-level keys
+// with string, integer or boolean values, and comments.
+package config
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Setting describes a key of the config file and the flag it provides the
+// default for. Without `Commands`, the flag of every command gets it.
+type Setting struct {
+	Key      string
+	Flag     string
+	Commands []string
+	Secret   bool
+}
+
+// Settings lists every key the config file may hold, in the order they are
+// shown.
+var Settings = []Setting{
+	{Key: "session", Flag: "session", Secret: true},
+	{Key: "year", Flag: "year"},
+	{Key: "cache_dir", Flag: "cache-dir"},
+	{Key: "format", Flag: "format", Commands: []string{"run"}},
+	{Key: "user_agent", Flag: "user-agent"},
+	{Key: "log_level", Flag: "log-level"},
+	{Key: "examples_dir", Flag: "examples-dir"},
+}
+
+// Config holds the values found in a config file by key.
+type Config map[string]string
+
+var (
+	reKey   = regexp.MustCompile(`^([A-Za-z0-9_-]+)\s*=\s*(.*)$`)
+	reValue = regexp.MustCompile(`^(?:"(?:[^"\\]|\\.)*"|'[^']*'|[+-]?[0-9_]+|true|false)`)
+)
+
+// Path returns the location of the config file: `AOC_CONFIG` when set,
+// otherwise aoc2024/config.toml in the user config directory, which is
+// $XDG_CONFIG_HOME or ~/.config on Linux.
+func Path() (string, error) {
+	if path := os.Getenv("AOC_CONFIG"); path != "" {
+		return path, nil
+	}
+
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("find config directory: %w", err)
+	}
+	return filepath.Join(dir, "aoc2024", "config.toml"), nil
+}
+
+// Load reads the config file at `path`. A missing file is an empty config.
+func Load(path string) (Config, error) {
+	f, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return Config{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("open config: %w", err)
+	}
+	defer f.Close()
+
+	cfg, err := Parse(f)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return cfg, nil
+}
+
+// Parse reads a config file, refusing keys that are not in `Settings`. A
+// leading "~/" in cache_dir stands for the home directory.
+func Parse(r io.Reader) (Config, error) {
+	cfg := Config{}
+
+	scanner := bufio.NewScanner(r)
+	for lineNr := 1; scanner.Scan(); lineNr++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		caps := reKey.FindStringSubmatch(line)
+		if caps == nil {
+			return nil, fmt.Errorf("line %d: expected key = value, got %q", lineNr, line)
+		}
+
+		key := caps[1]
+		if _, ok := Lookup(key); !ok {
+			return nil, fmt.Errorf("line %d: unknown setting %q", lineNr, key)
+		}
+
+		value, err := parseValue(caps[2])
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %s: %w", lineNr, key, err)
+		}
+
+		if key == "cache_dir" {
+			if value, err = expandHome(value); err != nil {
+				return nil, err
+			}
+		}
+
+		cfg[key] = value
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read config: %w", err)
+	}
+
+	return cfg, nil
+}
+
+// Lookup returns the setting of `key`.
+func Lookup(key string) (Setting, bool) {
+	for _, s := range Settings {
+		if s.Key == key {
+			return s, true
+		}
+	}
+	return Setting{}, false
+}
+
+// Mask hides all but the last four characters of a secret.
+func Mask(secret string) string {
+	if len(secret) <= 8 {
+		return strings.Repeat("*", len(secret))
+	}
+	return strings.Repeat("*", len(secret)-4) + secret[len(secret)-4:]
+}
+
+func parseValue(raw string) (string, error) {
+	token := reValue.FindString(raw)
+	if token == "" {
+		return "", fmt.Errorf("unsupported value %q", raw)
+	}
+
+	if rest := strings.TrimSpace(raw[len(token):]); rest != "" && !strings.HasPrefix(rest, "#") {
+		return "", fmt.Errorf("unexpected %q after the value", rest)
+	}
+
+	switch token[0] {
+	case '"':
+		value, err := strconv.Unquote(token)
+		if err != nil {
+			return "", fmt.Errorf("invalid string %s", token)
+		}
+		return value, nil
+	case '\'':
+		return token[1 : len(token)-1], nil
+	default:
+		return strings.ReplaceAll(token, "_", ""), nil
+	}
+}
+
+func expandHome(path string) (string, error) {
+	rest, ok := strings.CutPrefix(path, "~/")
+	if !ok {
+		return path, nil
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("expand %s: %w", path, err)
+	}
+	return filepath.Join(home, rest), nil
+}
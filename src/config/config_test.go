@@ -0,0 +1,76 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		t.Skip(err)
+	}
+
+	cfg, err := Parse(strings.NewReader(`# settings of aoc2024
+session = "53616c7465645f5f" # from the browser
+year = 2023
+cache_dir = '~/aoc'
+
+format = "json"
+user_agent = "aoc2024 (me@example.com, \"quoted\")"
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := Config{
+		"session":    "53616c7465645f5f",
+		"year":       "2023",
+		"cache_dir":  filepath.Join(home, "aoc"),
+		"format":     "json",
+		"user_agent": `aoc2024 (me@example.com, "quoted")`,
+	}
+	if len(cfg) != len(want) {
+		t.Fatalf("Parse() = %v, want %v", cfg, want)
+	}
+	for key, value := range want {
+		if cfg[key] != value {
+			t.Fatalf("Parse()[%v] = %q, want %q", key, cfg[key], value)
+		}
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	for _, text := range []string{
+		"sesion = \"typo\"",
+		"[aoc]\nyear = 2023",
+		"year = 2023 2024",
+		"session = \"unterminated",
+		"session = bare",
+	} {
+		if cfg, err := Parse(strings.NewReader(text)); err == nil {
+			t.Errorf("Parse(%q) = %v, want an error", text, cfg)
+		}
+	}
+}
+
+func TestLoadMissing(t *testing.T) {
+	cfg, err := Load(filepath.Join(t.TempDir(), "config.toml"))
+	if err != nil || len(cfg) != 0 {
+		t.Fatalf("Load(missing) = %v, %v, want an empty config", cfg, err)
+	}
+}
+
+func TestMask(t *testing.T) {
+	for secret, want := range map[string]string{
+		"":                 "",
+		"short":            "*****",
+		"53616c7465645f5f": "************5f5f",
+	} {
+		if got := Mask(secret); got != want {
+			t.Errorf("Mask(%q) = %q, want %q", secret, got, want)
+		}
+	}
+}
@@ -0,0 +1,118 @@
+package grid
+
+import (
+	L "github.com/wthys/advent-of-code-2024/location"
+)
+
+type (
+	// `Interface` is implemented by both the sparse `Grid` and the `Dense`
+	// grid, so solutions can switch between them.
+	Interface[T any] interface {
+		Reader[T]
+		Set(loc L.Location, value T)
+		Remove(loc L.Location)
+	}
+
+	// `Dense` stores the values of a fixed rectangle in a flat slice, which is
+	// faster and leaner than `Grid` for fully populated inputs.
+	Dense[T any] struct {
+		defaultFunc DefaultFunction[T]
+		bounds      Bounds
+		data        []T
+		stored      []bool
+		count       int
+	}
+)
+
+var (
+	_ Interface[int] = (*Grid[int])(nil)
+	_ Interface[int] = (*Dense[int])(nil)
+)
+
+// `NewDense` creates a `Dense` grid covering `bounds`, using the `DefaultError`
+// `DefaultFunction` for `Location`s without a value. Equivalent to
+// `DenseWithDefaultFunc(bounds, DefaultError())`.
+func NewDense[T any](bounds Bounds) *Dense[T] {
+	return DenseWithDefaultFunc(bounds, DefaultError[T]())
+}
+
+// `DenseWithDefault` creates a `Dense` grid covering `bounds`, using the
+// `DefaultValue` `DefaultFunction` for `Location`s without a value.
+func DenseWithDefault[T any](bounds Bounds, value T) *Dense[T] {
+	return DenseWithDefaultFunc(bounds, DefaultValue(value))
+}
+
+// `DenseWithDefaultFunc` creates a `Dense` grid covering `bounds`, using the
+// provided `DefaultFunction` for `Location`s without a value.
+func DenseWithDefaultFunc[T any](bounds Bounds, defaultFunc DefaultFunction[T]) *Dense[T] {
+	size := max(bounds.Width(), 0) * max(bounds.Height(), 0)
+	return &Dense[T]{defaultFunc, bounds, make([]T, size), make([]bool, size), 0}
+}
+
+func (g *Dense[T]) index(loc L.Location) (int, bool) {
+	if !g.bounds.Has(loc) {
+		return 0, false
+	}
+	return (loc.Y-g.bounds.Ymin)*g.bounds.Width() + loc.X - g.bounds.Xmin, true
+}
+
+// `Get` retrieves the value stored at `loc`. If there is no value stored,
+// including outside of the bounds, the `DefaultFunction` is called.
+func (g *Dense[T]) Get(loc L.Location) (T, error) {
+	idx, ok := g.index(loc)
+	if ok && g.stored[idx] {
+		return g.data[idx], nil
+	}
+	if g.defaultFunc == nil {
+		return DefaultError[T]()(loc)
+	}
+
+	return g.defaultFunc(loc)
+}
+
+// `Set` stores a value at `loc`. Panics when `loc` is outside of the bounds.
+func (g *Dense[T]) Set(loc L.Location, value T) {
+	idx, ok := g.index(loc)
+	if !ok {
+		panic("grid: location " + loc.String() + " outside of dense grid bounds")
+	}
+
+	if !g.stored[idx] {
+		g.stored[idx] = true
+		g.count++
+	}
+	g.data[idx] = value
+}
+
+// `Remove` removes the stored value at `loc`, if any.
+func (g *Dense[T]) Remove(loc L.Location) {
+	idx, ok := g.index(loc)
+	if !ok || !g.stored[idx] {
+		return
+	}
+
+	g.stored[idx] = false
+	g.data[idx] = *new(T)
+	g.count--
+}
+
+// `ForEach` applies a function to all stored values, row by row.
+func (g *Dense[T]) ForEach(forEach ForEachFunction[T]) {
+	width := g.bounds.Width()
+	for idx, value := range g.data {
+		if g.stored[idx] {
+			forEach(L.New(g.bounds.Xmin+idx%width, g.bounds.Ymin+idx/width), value)
+		}
+	}
+}
+
+// `Bounds` returns the bounds the grid was created with, regardless of where
+// values are stored.
+func (g *Dense[T]) Bounds() (Bounds, error) {
+	return g.bounds, nil
+}
+
+// `Len` returns the number of stored values.
+func (g *Dense[T]) Len() int {
+	return g.count
+}
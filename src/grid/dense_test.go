@@ -0,0 +1,126 @@
+package grid
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/wthys/advent-of-code-2024/location"
+)
+
+func TestDenseGetSet(t *testing.T) {
+	g := DenseWithDefault(Bounds{-1, 2, 3, 5}, 7)
+
+	loc := location.New(2, 4)
+	g.Set(loc, 3)
+
+	if val, err := g.Get(loc); val != 3 || err != nil {
+		t.Fatalf("g.Get(%v) = %v, %v, want %v, %v", loc, val, err, 3, nil)
+	}
+
+	for _, other := range []location.Location{location.New(-1, 3), location.New(10, 10)} {
+		if val, err := g.Get(other); val != 7 || err != nil {
+			t.Fatalf("g.Get(%v) = %v, %v, want %v, %v", other, val, err, 7, nil)
+		}
+	}
+
+	g.Remove(loc)
+	if val, _ := g.Get(loc); val != 7 || g.Len() != 0 {
+		t.Fatalf("after g.Remove(%v): g.Get() = %v, g.Len() = %v, want %v, %v", loc, val, g.Len(), 7, 0)
+	}
+}
+
+func TestDenseGetWithError(t *testing.T) {
+	g := NewDense[int](Bounds{0, 1, 0, 1})
+
+	for _, loc := range []location.Location{location.New(0, 0), location.New(5, 0)} {
+		if _, err := g.Get(loc); err == nil {
+			t.Fatalf("g.Get(%v) gave no error", loc)
+		}
+	}
+}
+
+func TestDenseSetOutside(t *testing.T) {
+	g := NewDense[int](Bounds{0, 1, 0, 1})
+
+	defer func() {
+		if recover() == nil {
+			t.Fatalf("g.Set() outside of the bounds did not panic")
+		}
+	}()
+	g.Set(location.New(2, 0), 1)
+}
+
+// TestDenseLikeGrid runs the same operations on both implementations.
+func TestDenseLikeGrid(t *testing.T) {
+	bounds := Bounds{1, 3, 2, 7}
+	grids := map[string]Interface[int]{
+		"sparse": New[int](),
+		"dense":  NewDense[int](bounds),
+	}
+
+	for name, g := range grids {
+		t.Run(name, func(t *testing.T) {
+			g.Set(location.New(1, 2), 3)
+			g.Set(location.New(3, 7), 4)
+			g.Set(location.New(2, 5), 8)
+			g.Set(location.New(2, 5), 9)
+			g.Remove(location.New(2, 6))
+
+			if g.Len() != 3 {
+				t.Fatalf("g.Len() = %v, want %v", g.Len(), 3)
+			}
+
+			if got, err := g.Bounds(); got != bounds || err != nil {
+				t.Fatalf("g.Bounds() = %v, %v, want %v, %v", got, err, bounds, nil)
+			}
+
+			sum := 0
+			g.ForEach(func(_ location.Location, value int) {
+				sum += value
+			})
+			if sum != 16 {
+				t.Fatalf("sum of g.ForEach() = %v, want %v", sum, 16)
+			}
+		})
+	}
+}
+
+func BenchmarkGrids(b *testing.B) {
+	constructors := map[string]func(Bounds) Interface[int]{
+		"sparse": func(Bounds) Interface[int] { return WithDefault(0) },
+		"dense":  func(bounds Bounds) Interface[int] { return DenseWithDefault(bounds, 0) },
+	}
+
+	for _, size := range []int{141, 1000} {
+		bounds := Bounds{0, size - 1, 0, size - 1}
+
+		for _, name := range []string{"sparse", "dense"} {
+			b.Run(fmt.Sprintf("%s/fill/%d", name, size), func(b *testing.B) {
+				for range b.N {
+					g := constructors[name](bounds)
+					bounds.ForEach(func(loc location.Location) {
+						g.Set(loc, loc.X+loc.Y)
+					})
+				}
+			})
+
+			b.Run(fmt.Sprintf("%s/neighbours/%d", name, size), func(b *testing.B) {
+				g := constructors[name](bounds)
+				bounds.ForEach(func(loc location.Location) {
+					g.Set(loc, loc.X+loc.Y)
+				})
+
+				b.ResetTimer()
+				for range b.N {
+					sum := 0
+					bounds.ForEach(func(loc location.Location) {
+						for _, n := range loc.OrthoNeejbers() {
+							v, _ := g.Get(n)
+							sum += v
+						}
+					})
+				}
+			})
+		}
+	}
+}
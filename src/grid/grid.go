@@ -2,6 +2,7 @@ package grid
 
 import (
 	"fmt"
+	"io"
 
 	L "github.com/wthys/advent-of-code-2024/location"
 )
@@ -135,41 +136,28 @@ func (g *Grid[T]) Len() int {
 	return len(g.data)
 }
 
-func (g *Grid[T]) Print() {
-	g.PrintFunc(func(val T, err error) string {
-		if err != nil {
-			return "."
-		}
-		return fmt.Sprint(val)
-	})
+// `Print` writes the grid to `w`, see `Renderer` for colors and overlays.
+func (g *Grid[T]) Print(w io.Writer) error {
+	return Renderer[T]{}.Render(w, g)
 }
 
-func (g *Grid[T]) PrintFunc(stringer func(T, error) string) {
-	g.PrintFuncWithLoc(func (_ L.Location, v T, err error) string {
+// `PrintFunc` writes the grid to `w`, using `stringer` for the text of a cell.
+func (g *Grid[T]) PrintFunc(w io.Writer, stringer func(T, error) string) error {
+	return g.PrintFuncWithLoc(w, func (_ L.Location, v T, err error) string {
 		return stringer(v, err)
 	})
 }
 
-func (g *Grid[T]) PrintFuncWithLoc(stringer func(L.Location, T, error) string) {
-	bounds, err := g.Bounds()
-
-	if err != nil {
-		fmt.Println()
-		return
-	}
-
-	g.PrintBoundsFuncWithLoc(bounds, stringer)
+// `PrintFuncWithLoc` writes the grid to `w`, using `stringer` for the text of
+// a cell.
+func (g *Grid[T]) PrintFuncWithLoc(w io.Writer, stringer func(L.Location, T, error) string) error {
+	return Renderer[T]{Cell: stringer}.Render(w, g)
 }
 
-func (g *Grid[T]) PrintBoundsFuncWithLoc(bounds Bounds, stringer func(L.Location, T, error) string) {
-	for y := bounds.Ymin; y <= bounds.Ymax; y++ {
-		for x := bounds.Xmin; x <= bounds.Xmax; x++ {
-			pos := L.New(x, y)
-			val, err := g.Get(pos)
-			fmt.Print(stringer(pos, val, err))
-		}
-		fmt.Println()
-	}
+// `PrintBoundsFuncWithLoc` writes the part of the grid within `bounds` to
+// `w`, using `stringer` for the text of a cell.
+func (g *Grid[T]) PrintBoundsFuncWithLoc(w io.Writer, bounds Bounds, stringer func(L.Location, T, error) string) error {
+	return Renderer[T]{Cell: stringer}.RenderBounds(w, g, bounds)
 }
 
 func (b *Bounds) Has(loc L.Location) bool {
@@ -0,0 +1,210 @@
+package grid
+
+import (
+	"bufio"
+	"fmt"
+	"image"
+	"image/color"
+	"image/color/palette"
+	"image/draw"
+	"image/gif"
+	"image/png"
+	"io"
+
+	L "github.com/wthys/advent-of-code-2024/location"
+)
+
+type (
+	// `Palette` returns the color of a cell.
+	Palette[T any] func(loc L.Location, value T, err error) color.Color
+
+	// `Painter` draws grids as images, every cell a square of `Scale` pixels.
+	Painter[T any] struct {
+		// `Palette` colors the cells, by default black for values and
+		// transparent for cells without one.
+		Palette Palette[T]
+		// `Scale` is the size of a cell in pixels, 1 when not set.
+		Scale int
+		// `Bounds` is the part of the grid to draw, the `Bounds` of the grid
+		// when nil.
+		Bounds *Bounds
+	}
+
+	// `Animation` records grids as the frames of an animated GIF.
+	Animation[T any] struct {
+		Painter[T]
+		// `Delay` is the time a frame is shown, in hundredths of a second.
+		Delay int
+
+		frames []*image.RGBA
+	}
+)
+
+// `ColorMap` creates a `Palette` looking up the color of every value in
+// `colors`, using `other` for cells without a value or a color.
+func ColorMap[T comparable](colors map[T]color.Color, other color.Color) Palette[T] {
+	return func(_ L.Location, value T, err error) color.Color {
+		if c, ok := colors[value]; ok && err == nil {
+			return c
+		}
+		return other
+	}
+}
+
+func (p Painter[T]) bounds(g Reader[T]) (Bounds, error) {
+	if p.Bounds != nil {
+		return *p.Bounds, nil
+	}
+	return g.Bounds()
+}
+
+func (p Painter[T]) scale() int {
+	return max(p.Scale, 1)
+}
+
+func (p Painter[T]) color(g Reader[T], loc L.Location) color.Color {
+	value, err := g.Get(loc)
+	if p.Palette != nil {
+		return p.Palette(loc, value, err)
+	}
+	if err != nil {
+		return color.Transparent
+	}
+	return color.Black
+}
+
+// `Image` draws the grid.
+func (p Painter[T]) Image(g Reader[T]) (*image.RGBA, error) {
+	bounds, err := p.bounds(g)
+	if err != nil {
+		return nil, err
+	}
+
+	scale := p.scale()
+	img := image.NewRGBA(image.Rect(0, 0, bounds.Width()*scale, bounds.Height()*scale))
+
+	bounds.ForEach(func(loc L.Location) {
+		x, y := (loc.X-bounds.Xmin)*scale, (loc.Y-bounds.Ymin)*scale
+		cell := image.Rect(x, y, x+scale, y+scale)
+		draw.Draw(img, cell, image.NewUniform(p.color(g, loc)), image.Point{}, draw.Src)
+	})
+
+	return img, nil
+}
+
+// `WritePNG` draws the grid as a PNG image.
+func (p Painter[T]) WritePNG(w io.Writer, g Reader[T]) error {
+	img, err := p.Image(g)
+	if err != nil {
+		return err
+	}
+	return png.Encode(w, img)
+}
+
+// `WriteSVG` draws the grid as an SVG image, merging the cells of a row that
+// share a color into a single rectangle.
+func (p Painter[T]) WriteSVG(w io.Writer, g Reader[T]) error {
+	bounds, err := p.bounds(g)
+	if err != nil {
+		return err
+	}
+
+	scale := p.scale()
+	width, height := bounds.Width()*scale, bounds.Height()*scale
+
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" shape-rendering="crispEdges">`+"\n",
+		width, height, width, height)
+
+	for y := bounds.Ymin; y <= bounds.Ymax; y++ {
+		for x := bounds.Xmin; x <= bounds.Xmax; {
+			c := color.NRGBAModel.Convert(p.color(g, L.New(x, y))).(color.NRGBA)
+
+			run := 1
+			for x+run <= bounds.Xmax && color.NRGBAModel.Convert(p.color(g, L.New(x+run, y))) == c {
+				run++
+			}
+
+			if c.A > 0 {
+				fmt.Fprintf(bw, `<rect x="%d" y="%d" width="%d" height="%d" fill="#%02x%02x%02x"`,
+					(x-bounds.Xmin)*scale, (y-bounds.Ymin)*scale, run*scale, scale, c.R, c.G, c.B)
+				if c.A < 255 {
+					fmt.Fprintf(bw, ` fill-opacity="%.3f"`, float64(c.A)/255)
+				}
+				fmt.Fprint(bw, "/>\n")
+			}
+
+			x += run
+		}
+	}
+
+	fmt.Fprint(bw, "</svg>\n")
+	return bw.Flush()
+}
+
+// `AddFrame` draws the grid as the next frame. The first frame fixes the
+// `Bounds` of the animation, when they were not set.
+func (a *Animation[T]) AddFrame(g Reader[T]) error {
+	if a.Bounds == nil {
+		bounds, err := g.Bounds()
+		if err != nil {
+			return err
+		}
+		a.Bounds = &bounds
+	}
+
+	img, err := a.Image(g)
+	if err != nil {
+		return err
+	}
+
+	a.frames = append(a.frames, img)
+	return nil
+}
+
+// `Len` returns the number of frames.
+func (a *Animation[T]) Len() int {
+	return len(a.frames)
+}
+
+// `WriteGIF` writes the frames as an animated GIF, looping forever. Up to 256
+// colors are kept as they are, more are mapped onto the Plan 9 palette.
+func (a *Animation[T]) WriteGIF(w io.Writer) error {
+	if len(a.frames) == 0 {
+		return fmt.Errorf("no frames in animation")
+	}
+
+	pal := a.palette()
+
+	anim := gif.GIF{}
+	for _, frame := range a.frames {
+		paletted := image.NewPaletted(frame.Bounds(), pal)
+		draw.Draw(paletted, frame.Bounds(), frame, image.Point{}, draw.Src)
+
+		anim.Image = append(anim.Image, paletted)
+		anim.Delay = append(anim.Delay, a.Delay)
+	}
+
+	return gif.EncodeAll(w, &anim)
+}
+
+func (a *Animation[T]) palette() color.Palette {
+	seen := map[color.RGBA]bool{}
+	pal := color.Palette{}
+
+	for _, frame := range a.frames {
+		for idx := 0; idx < len(frame.Pix); idx += 4 {
+			c := color.RGBA{frame.Pix[idx], frame.Pix[idx+1], frame.Pix[idx+2], frame.Pix[idx+3]}
+			if seen[c] {
+				continue
+			}
+			if len(pal) == 256 {
+				return palette.Plan9
+			}
+			seen[c] = true
+			pal = append(pal, c)
+		}
+	}
+
+	return pal
+}
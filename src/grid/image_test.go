@@ -0,0 +1,112 @@
+package grid
+
+import (
+	"bytes"
+	"image/color"
+	"image/gif"
+	"image/png"
+	"strings"
+	"testing"
+
+	"github.com/wthys/advent-of-code-2024/location"
+)
+
+var mazeColors = ColorMap(map[rune]color.Color{
+	'#': color.Black,
+	'S': color.RGBA{0, 255, 0, 255},
+	'E': color.RGBA{255, 0, 0, 255},
+}, color.White)
+
+func TestWritePNG(t *testing.T) {
+	g, _ := FromLines(maze, Runes)
+
+	var buf bytes.Buffer
+	if err := (Painter[rune]{Palette: mazeColors, Scale: 3}).WritePNG(&buf, g); err != nil {
+		t.Fatal(err)
+	}
+
+	img, err := png.Decode(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if size := img.Bounds().Size(); size.X != 15 || size.Y != 12 {
+		t.Fatalf("image size = %v, want 15x12", size)
+	}
+
+	for _, check := range []struct {
+		x, y int
+		want color.Color
+	}{
+		{0, 0, color.Black},
+		{5, 5, color.RGBA{0, 255, 0, 255}},
+		{8, 4, color.White},
+		{11, 3, color.RGBA{255, 0, 0, 255}},
+	} {
+		got := color.RGBAModel.Convert(img.At(check.x, check.y))
+		if got != color.RGBAModel.Convert(check.want) {
+			t.Errorf("img.At(%v, %v) = %v, want %v", check.x, check.y, got, check.want)
+		}
+	}
+}
+
+func TestWriteSVG(t *testing.T) {
+	g, _ := FromLines(maze, Runes, Background("#"), SkipBackground())
+
+	var sb strings.Builder
+	if err := (Painter[rune]{Scale: 2}).WriteSVG(&sb, g); err != nil {
+		t.Fatal(err)
+	}
+
+	svg := sb.String()
+	if !strings.HasPrefix(svg, `<svg xmlns="http://www.w3.org/2000/svg" width="6" height="4"`) {
+		t.Fatalf("WriteSVG() = %s, want a 6x4 image of the bounds of the stored cells", svg)
+	}
+
+	want := []string{
+		`<rect x="0" y="0" width="6" height="2" fill="#000000"/>`,
+		`<rect x="0" y="2" width="2" height="2" fill="#000000"/>`,
+		`<rect x="4" y="2" width="2" height="2" fill="#000000"/>`,
+	}
+	if strings.Count(svg, "<rect") != len(want) {
+		t.Fatalf("WriteSVG() = %s, want %v rectangles", svg, len(want))
+	}
+	for _, rect := range want {
+		if !strings.Contains(svg, rect) {
+			t.Fatalf("WriteSVG() = %s, want it to contain %s", svg, rect)
+		}
+	}
+}
+
+func TestAnimation(t *testing.T) {
+	anim := Animation[rune]{Painter: Painter[rune]{Palette: mazeColors, Scale: 2}, Delay: 10}
+
+	g, _ := FromLines(maze, Runes)
+	for _, loc := range []location.Location{location.New(2, 1), location.New(1, 2)} {
+		g.Set(loc, 'S')
+		if err := anim.AddFrame(g); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := anim.WriteGIF(&buf); err != nil {
+		t.Fatal(err)
+	}
+
+	decoded, err := gif.DecodeAll(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(decoded.Image) != 2 || decoded.Delay[1] != 10 {
+		t.Fatalf("GIF has %v frames with delays %v, want 2 frames of 10", len(decoded.Image), decoded.Delay)
+	}
+	if size := decoded.Image[0].Bounds().Size(); size.X != 10 || size.Y != 8 {
+		t.Fatalf("frame size = %v, want 10x8", size)
+	}
+
+	if err := (&Animation[rune]{}).WriteGIF(&buf); err == nil {
+		t.Fatalf("WriteGIF() without frames gave no error")
+	}
+}
@@ -0,0 +1,156 @@
+package grid
+
+import (
+	"fmt"
+	"strings"
+
+	L "github.com/wthys/advent-of-code-2024/location"
+)
+
+type (
+	// `Mapper` converts a character of the input into the value stored for it.
+	Mapper[T any] func(char rune) (T, error)
+
+	// `Catalog` lists the `Location`s of the special characters of the input,
+	// in reading order.
+	Catalog map[rune]L.Locations
+
+	// `ParseOption` tunes how input lines are read into a grid.
+	ParseOption func(*parseConfig)
+
+	parseConfig struct {
+		background     string
+		skipBackground bool
+		marks          string
+	}
+)
+
+// `Background` marks `chars` as background: they are never cataloged.
+func Background(chars string) ParseOption {
+	return func(cfg *parseConfig) {
+		cfg.background += chars
+	}
+}
+
+// `SkipBackground` leaves the background characters out of the grid, so a
+// sparse grid only stores the meaningful cells.
+func SkipBackground() ParseOption {
+	return func(cfg *parseConfig) {
+		cfg.skipBackground = true
+	}
+}
+
+// `Mark` only catalogs `chars`, instead of every character that is not
+// background.
+func Mark(chars string) ParseOption {
+	return func(cfg *parseConfig) {
+		cfg.marks += chars
+	}
+}
+
+// `Runes` stores the characters as they are.
+func Runes(char rune) (rune, error) {
+	return char, nil
+}
+
+// `Strings` stores the characters as strings.
+func Strings(char rune) (string, error) {
+	return string(char), nil
+}
+
+// `Digits` stores the characters as their digit value, failing for anything
+// but '0' to '9'.
+func Digits(char rune) (int, error) {
+	if char < '0' || char > '9' {
+		return 0, fmt.Errorf("%q is not a digit", char)
+	}
+	return int(char - '0'), nil
+}
+
+// `Into` stores the characters of `lines` into `g`, with the first character
+// of the first line at (0,0), and returns the `Catalog` of the input.
+func Into[T any](g Interface[T], lines []string, mapper Mapper[T], options ...ParseOption) (Catalog, error) {
+	cfg := parseConfig{}
+	for _, option := range options {
+		option(&cfg)
+	}
+
+	catalog := Catalog{}
+	for y, line := range lines {
+		for x, char := range []rune(line) {
+			loc := L.New(x, y)
+
+			background := strings.ContainsRune(cfg.background, char)
+			if !background && (cfg.marks == "" || strings.ContainsRune(cfg.marks, char)) {
+				catalog[char] = append(catalog[char], loc)
+			}
+
+			if background && cfg.skipBackground {
+				continue
+			}
+
+			value, err := mapper(char)
+			if err != nil {
+				return catalog, fmt.Errorf("line %d, column %d: %w", y+1, x+1, err)
+			}
+			g.Set(loc, value)
+		}
+	}
+
+	return catalog, nil
+}
+
+// `FromLines` creates a `Grid` holding the characters of `lines`, as
+// converted by `mapper`.
+func FromLines[T any](lines []string, mapper Mapper[T], options ...ParseOption) (*Grid[T], error) {
+	g, _, err := FromLinesWithCatalog(lines, mapper, options...)
+	return g, err
+}
+
+// `FromLinesWithCatalog` is `FromLines`, also returning the `Catalog` of the
+// input.
+func FromLinesWithCatalog[T any](lines []string, mapper Mapper[T], options ...ParseOption) (*Grid[T], Catalog, error) {
+	g := New[T]()
+	catalog, err := Into(g, lines, mapper, options...)
+	if err != nil {
+		return nil, nil, err
+	}
+	return g, catalog, nil
+}
+
+// `DenseFromLines` creates a `Dense` grid holding the characters of `lines`,
+// as converted by `mapper`. The bounds fit the longest line.
+func DenseFromLines[T any](lines []string, mapper Mapper[T], options ...ParseOption) (*Dense[T], error) {
+	g, _, err := DenseFromLinesWithCatalog(lines, mapper, options...)
+	return g, err
+}
+
+// `DenseFromLinesWithCatalog` is `DenseFromLines`, also returning the
+// `Catalog` of the input.
+func DenseFromLinesWithCatalog[T any](lines []string, mapper Mapper[T], options ...ParseOption) (*Dense[T], Catalog, error) {
+	g := NewDense[T](LinesBounds(lines))
+	catalog, err := Into(g, lines, mapper, options...)
+	if err != nil {
+		return nil, nil, err
+	}
+	return g, catalog, nil
+}
+
+// `LinesBounds` returns the `Bounds` covering every character of `lines`.
+func LinesBounds(lines []string) Bounds {
+	width := 0
+	for _, line := range lines {
+		width = max(width, len([]rune(line)))
+	}
+	return Bounds{0, width - 1, 0, len(lines) - 1}
+}
+
+// `Single` returns the only `Location` of `char`, failing when it appears
+// more or less than once.
+func (c Catalog) Single(char rune) (L.Location, error) {
+	locs := c[char]
+	if len(locs) != 1 {
+		return L.Location{}, fmt.Errorf("found %q %d times, want once", char, len(locs))
+	}
+	return locs[0], nil
+}
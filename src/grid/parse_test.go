@@ -0,0 +1,82 @@
+package grid
+
+import (
+	"testing"
+
+	"github.com/wthys/advent-of-code-2024/location"
+)
+
+var maze = []string{
+	"#####",
+	"#S.E#",
+	"#.#.#",
+	"#####",
+}
+
+func TestFromLines(t *testing.T) {
+	g, err := FromLines(maze, Strings)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if g.Len() != 20 {
+		t.Fatalf("g.Len() = %v, want %v", g.Len(), 20)
+	}
+
+	loc := location.New(3, 1)
+	if val, err := g.Get(loc); val != "E" || err != nil {
+		t.Fatalf("g.Get(%v) = %v, %v, want %v, %v", loc, val, err, "E", nil)
+	}
+}
+
+func TestFromLinesWithCatalog(t *testing.T) {
+	g, catalog, err := FromLinesWithCatalog(maze, Runes, Background("#"), SkipBackground())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if g.Len() != 5 {
+		t.Fatalf("g.Len() = %v, want %v", g.Len(), 5)
+	}
+
+	want := location.Locations{location.New(2, 1), location.New(1, 2), location.New(3, 2)}
+	if got := catalog['.']; len(got) != len(want) || got[0] != want[0] || got[1] != want[1] || got[2] != want[2] {
+		t.Fatalf("catalog['.'] = %v, want %v", got, want)
+	}
+	if _, ok := catalog['#']; ok {
+		t.Fatalf("catalog has background %q", '#')
+	}
+
+	if start, err := catalog.Single('S'); start != location.New(1, 1) || err != nil {
+		t.Fatalf("catalog.Single('S') = %v, %v, want %v, %v", start, err, location.New(1, 1), nil)
+	}
+	if _, err := catalog.Single('#'); err == nil {
+		t.Fatalf("catalog.Single('#') gave no error")
+	}
+}
+
+func TestDenseFromLinesMark(t *testing.T) {
+	g, catalog, err := DenseFromLinesWithCatalog(maze, Runes, Mark("SE"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if bounds, _ := g.Bounds(); bounds != (Bounds{0, 4, 0, 3}) {
+		t.Fatalf("g.Bounds() = %v, want %v", bounds, Bounds{0, 4, 0, 3})
+	}
+
+	if len(catalog) != 2 || len(catalog['S']) != 1 || len(catalog['E']) != 1 {
+		t.Fatalf("catalog = %v, want only S and E", catalog)
+	}
+}
+
+func TestFromLinesMapperError(t *testing.T) {
+	if _, err := FromLines([]string{"0123", "45x7"}, Digits); err == nil {
+		t.Fatalf("FromLines() with a bad digit gave no error")
+	}
+
+	g, err := DenseFromLines([]string{"0123", "4.67"}, Digits, Background("."), SkipBackground())
+	if err != nil || g.Len() != 7 {
+		t.Fatalf("DenseFromLines() = %v values, %v, want %v, %v", g.Len(), err, 7, nil)
+	}
+}
@@ -0,0 +1,159 @@
+package grid
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+
+	S "github.com/wthys/advent-of-code-2024/collections/set"
+	L "github.com/wthys/advent-of-code-2024/location"
+)
+
+type (
+	// `Color` is an ANSI foreground color.
+	Color int
+
+	// `Overlay` is drawn over the cells of a grid at the `Location`s it has.
+	Overlay struct {
+		// `Name` describes the overlay in the legend, which skips overlays
+		// without one.
+		Name string
+		// `Symbol` replaces the cell, which keeps its own text when empty.
+		Symbol string
+		Color  Color
+		Has    func(loc L.Location) bool
+	}
+
+	// `Renderer` writes grids as text, one character (or `Cell` text) per
+	// `Location`, with the `Overlays` drawn on top in order.
+	Renderer[T any] struct {
+		// `Cell` returns the text of a cell, by default "." for cells without
+		// a value and the formatted value otherwise.
+		Cell func(loc L.Location, value T, err error) string
+		// `CellColor` colors the cells that no overlay covers.
+		CellColor func(loc L.Location, value T, err error) Color
+		Overlays  []Overlay
+		// `Color` enables the ANSI colors, which are left out otherwise.
+		Color bool
+		// `Legend` adds a line per named overlay below the grid.
+		Legend bool
+	}
+)
+
+// `NoColor` leaves the text as it is.
+const NoColor Color = 0
+
+const (
+	Black Color = iota + 30
+	Red
+	Green
+	Yellow
+	Blue
+	Magenta
+	Cyan
+	White
+)
+
+const Gray Color = 90
+
+// `Paint` wraps `text` in the escape codes of the color.
+func (c Color) Paint(text string) string {
+	if c == NoColor {
+		return text
+	}
+	return fmt.Sprintf("\x1b[%dm%s\x1b[0m", int(c), text)
+}
+
+// `SetOverlay` creates an `Overlay` for the `Location`s in `locs`.
+func SetOverlay(name string, symbol string, color Color, locs *S.Set[L.Location]) Overlay {
+	return Overlay{name, symbol, color, locs.Has}
+}
+
+// `PathOverlay` creates an `Overlay` for the `Location`s of `path`.
+func PathOverlay(name string, symbol string, color Color, path L.Locations) Overlay {
+	return SetOverlay(name, symbol, color, S.New(path...))
+}
+
+// `LocationOverlay` creates an `Overlay` for a single `Location`, like a start
+// or an end.
+func LocationOverlay(name string, symbol string, color Color, loc L.Location) Overlay {
+	return Overlay{name, symbol, color, func(other L.Location) bool { return other == loc }}
+}
+
+// `Render` writes the part of `g` within its `Bounds`. An empty grid is
+// written as an empty line.
+func (r Renderer[T]) Render(w io.Writer, g Reader[T]) error {
+	bounds, err := g.Bounds()
+	if err != nil {
+		_, err := fmt.Fprintln(w)
+		return err
+	}
+
+	return r.RenderBounds(w, g, bounds)
+}
+
+// `RenderBounds` writes the part of `g` within `bounds`.
+func (r Renderer[T]) RenderBounds(w io.Writer, g Reader[T], bounds Bounds) error {
+	bw := bufio.NewWriter(w)
+
+	for y := bounds.Ymin; y <= bounds.Ymax; y++ {
+		for x := bounds.Xmin; x <= bounds.Xmax; x++ {
+			bw.WriteString(r.cell(g, L.New(x, y)))
+		}
+		bw.WriteString("\n")
+	}
+
+	if r.Legend {
+		for _, overlay := range r.Overlays {
+			if overlay.Name == "" {
+				continue
+			}
+			symbol := overlay.Symbol
+			if symbol == "" {
+				symbol = "*"
+			}
+			fmt.Fprintf(bw, "%s %s\n", r.paint(overlay.Color, symbol), overlay.Name)
+		}
+	}
+
+	return bw.Flush()
+}
+
+func (r Renderer[T]) cell(g Reader[T], loc L.Location) string {
+	value, err := g.Get(loc)
+
+	text := ""
+	if r.Cell != nil {
+		text = r.Cell(loc, value, err)
+	} else if err != nil {
+		text = "."
+	} else {
+		text = fmt.Sprint(value)
+	}
+
+	color := NoColor
+	if r.CellColor != nil {
+		color = r.CellColor(loc, value, err)
+	}
+
+	for _, overlay := range r.Overlays {
+		if !overlay.Has(loc) {
+			continue
+		}
+		if overlay.Symbol != "" {
+			text = overlay.Symbol
+		}
+		if overlay.Color != NoColor {
+			color = overlay.Color
+		}
+	}
+
+	return r.paint(color, text)
+}
+
+func (r Renderer[T]) paint(color Color, text string) string {
+	if !r.Color {
+		return text
+	}
+	return color.Paint(text)
+}
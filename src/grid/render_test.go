@@ -0,0 +1,72 @@
+package grid
+
+import (
+	"strings"
+	"testing"
+
+	S "github.com/wthys/advent-of-code-2024/collections/set"
+	"github.com/wthys/advent-of-code-2024/location"
+)
+
+func TestRender(t *testing.T) {
+	g, _ := FromLines(maze, Strings)
+
+	var sb strings.Builder
+	if err := (Renderer[string]{}).Render(&sb, g); err != nil {
+		t.Fatal(err)
+	}
+
+	want := strings.Join(maze, "\n") + "\n"
+	if sb.String() != want {
+		t.Fatalf("Render() =\n%s\nwant\n%s", sb.String(), want)
+	}
+
+	sb.Reset()
+	if err := g.Print(&sb); err != nil || sb.String() != want {
+		t.Fatalf("Print() =\n%s\n%v, want\n%s", sb.String(), err, want)
+	}
+}
+
+func TestRenderOverlays(t *testing.T) {
+	g, _ := FromLines(maze, Strings, Background("#"), SkipBackground())
+
+	visited := S.New(location.New(1, 1), location.New(2, 1), location.New(1, 2))
+	r := Renderer[string]{
+		Cell: func(_ location.Location, v string, err error) string {
+			if err != nil {
+				return " "
+			}
+			return v
+		},
+		Overlays: []Overlay{
+			SetOverlay("visited", "o", Blue, visited),
+			LocationOverlay("", "", Red, location.New(1, 2)),
+		},
+		Legend: true,
+	}
+
+	var sb strings.Builder
+	if err := r.RenderBounds(&sb, g, Bounds{1, 3, 1, 2}); err != nil {
+		t.Fatal(err)
+	}
+
+	want := "ooE\no .\no visited\n"
+	if sb.String() != want {
+		t.Fatalf("RenderBounds() =\n%q\nwant\n%q", sb.String(), want)
+	}
+
+	r.Color = true
+	sb.Reset()
+	r.RenderBounds(&sb, g, Bounds{1, 1, 2, 2})
+	want = Red.Paint("o") + "\n" + Blue.Paint("o") + " visited\n"
+	if sb.String() != want {
+		t.Fatalf("RenderBounds() with color = %q, want %q", sb.String(), want)
+	}
+}
+
+func TestRenderEmpty(t *testing.T) {
+	var sb strings.Builder
+	if err := (Renderer[int]{}).Render(&sb, New[int]()); err != nil || sb.String() != "\n" {
+		t.Fatalf("Render() of an empty grid = %q, %v, want %q, %v", sb.String(), err, "\n", nil)
+	}
+}
@@ -0,0 +1,210 @@
+package grid
+
+import (
+	L "github.com/wthys/advent-of-code-2024/location"
+)
+
+type (
+	// `Reader` is the read-only part of `Interface`.
+	Reader[T any] interface {
+		Get(loc L.Location) (T, error)
+		ForEach(forEach ForEachFunction[T])
+		Bounds() (Bounds, error)
+		Len() int
+	}
+
+	// `View` is a read-only window on a grid, showing the values within its
+	// `Bounds` at their own `Location`s. It copies nothing, so changes to the
+	// grid show through.
+	View[T any] struct {
+		grid   Reader[T]
+		bounds Bounds
+	}
+
+	// move tells where a `Location` ends up after a transformation.
+	move func(loc L.Location) L.Location
+)
+
+var _ Reader[int] = (*View[int])(nil)
+
+// `Window` creates a `View` on the part of `g` within `bounds`.
+func Window[T any](g Reader[T], bounds Bounds) *View[T] {
+	return &View[T]{g, bounds}
+}
+
+// `Get` retrieves the value at `loc` from the grid, or the `DefaultError`
+// outside of the window.
+func (v *View[T]) Get(loc L.Location) (T, error) {
+	if !v.bounds.Has(loc) {
+		return DefaultError[T]()(loc)
+	}
+	return v.grid.Get(loc)
+}
+
+// `ForEach` applies a function to every `Location` of the window the grid
+// has a value for, including default values, row by row.
+func (v *View[T]) ForEach(forEach ForEachFunction[T]) {
+	v.bounds.ForEach(func(loc L.Location) {
+		if value, err := v.grid.Get(loc); err == nil {
+			forEach(loc, value)
+		}
+	})
+}
+
+// `Bounds` returns the bounds of the window.
+func (v *View[T]) Bounds() (Bounds, error) {
+	return v.bounds, nil
+}
+
+// `Len` returns the number of `Location`s `ForEach` visits.
+func (v *View[T]) Len() int {
+	count := 0
+	v.ForEach(func(_ L.Location, _ T) {
+		count++
+	})
+	return count
+}
+
+// rotate90 turns `b` clockwise around its top left corner.
+func rotate90(b Bounds) (Bounds, move) {
+	rotated := Bounds{b.Xmin, b.Xmin + b.Height() - 1, b.Ymin, b.Ymin + b.Width() - 1}
+	return rotated, func(loc L.Location) L.Location {
+		return L.New(b.Xmin+b.Ymax-loc.Y, b.Ymin+loc.X-b.Xmin)
+	}
+}
+
+func flipH(b Bounds) (Bounds, move) {
+	return b, func(loc L.Location) L.Location {
+		return L.New(b.Xmin+b.Xmax-loc.X, loc.Y)
+	}
+}
+
+func flipV(b Bounds) (Bounds, move) {
+	return b, func(loc L.Location) L.Location {
+		return L.New(loc.X, b.Ymin+b.Ymax-loc.Y)
+	}
+}
+
+// transpose mirrors `b` in the diagonal through its top left corner.
+func transpose(b Bounds) (Bounds, move) {
+	transposed := Bounds{b.Xmin, b.Xmin + b.Height() - 1, b.Ymin, b.Ymin + b.Width() - 1}
+	return transposed, func(loc L.Location) L.Location {
+		return L.New(b.Xmin+loc.Y-b.Ymin, b.Ymin+loc.X-b.Xmin)
+	}
+}
+
+func translate(b Bounds, offset L.Location) (Bounds, move) {
+	moved := Bounds{b.Xmin + offset.X, b.Xmax + offset.X, b.Ymin + offset.Y, b.Ymax + offset.Y}
+	return moved, func(loc L.Location) L.Location {
+		return loc.Add(offset)
+	}
+}
+
+func (g *Grid[T]) transform(transformation func(Bounds) (Bounds, move)) *Grid[T] {
+	out := WithDefaultFunc(g.defaultFunc)
+
+	bounds, err := g.Bounds()
+	if err != nil {
+		return out
+	}
+
+	_, moveTo := transformation(bounds)
+	g.ForEach(func(loc L.Location, value T) {
+		out.Set(moveTo(loc), value)
+	})
+	return out
+}
+
+// `Rotate90` returns a copy of the grid turned clockwise, keeping the top
+// left corner of its `Bounds` in place.
+func (g *Grid[T]) Rotate90() *Grid[T] {
+	return g.transform(rotate90)
+}
+
+// `FlipH` returns a copy of the grid mirrored left to right within its
+// `Bounds`.
+func (g *Grid[T]) FlipH() *Grid[T] {
+	return g.transform(flipH)
+}
+
+// `FlipV` returns a copy of the grid mirrored top to bottom within its
+// `Bounds`.
+func (g *Grid[T]) FlipV() *Grid[T] {
+	return g.transform(flipV)
+}
+
+// `Transpose` returns a copy of the grid with rows and columns swapped,
+// keeping the top left corner of its `Bounds` in place.
+func (g *Grid[T]) Transpose() *Grid[T] {
+	return g.transform(transpose)
+}
+
+// `Translate` returns a copy of the grid with every value moved by `offset`.
+func (g *Grid[T]) Translate(offset L.Location) *Grid[T] {
+	return g.transform(func(b Bounds) (Bounds, move) {
+		return translate(b, offset)
+	})
+}
+
+// `Crop` returns a copy of the values of the grid within `bounds`.
+func (g *Grid[T]) Crop(bounds Bounds) *Grid[T] {
+	out := WithDefaultFunc(g.defaultFunc)
+	g.ForEach(func(loc L.Location, value T) {
+		if bounds.Has(loc) {
+			out.Set(loc, value)
+		}
+	})
+	return out
+}
+
+func (g *Dense[T]) transform(transformation func(Bounds) (Bounds, move)) *Dense[T] {
+	bounds, moveTo := transformation(g.bounds)
+
+	out := DenseWithDefaultFunc(bounds, g.defaultFunc)
+	g.ForEach(func(loc L.Location, value T) {
+		out.Set(moveTo(loc), value)
+	})
+	return out
+}
+
+// `Rotate90` returns a copy of the grid turned clockwise, keeping the top
+// left corner of its `Bounds` in place.
+func (g *Dense[T]) Rotate90() *Dense[T] {
+	return g.transform(rotate90)
+}
+
+// `FlipH` returns a copy of the grid mirrored left to right.
+func (g *Dense[T]) FlipH() *Dense[T] {
+	return g.transform(flipH)
+}
+
+// `FlipV` returns a copy of the grid mirrored top to bottom.
+func (g *Dense[T]) FlipV() *Dense[T] {
+	return g.transform(flipV)
+}
+
+// `Transpose` returns a copy of the grid with rows and columns swapped,
+// keeping the top left corner of its `Bounds` in place.
+func (g *Dense[T]) Transpose() *Dense[T] {
+	return g.transform(transpose)
+}
+
+// `Translate` returns a copy of the grid with its `Bounds` and every value
+// moved by `offset`.
+func (g *Dense[T]) Translate(offset L.Location) *Dense[T] {
+	return g.transform(func(b Bounds) (Bounds, move) {
+		return translate(b, offset)
+	})
+}
+
+// `Crop` returns a copy of the grid covering `bounds`, holding the values
+// within them.
+func (g *Dense[T]) Crop(bounds Bounds) *Dense[T] {
+	out := DenseWithDefaultFunc(bounds, g.defaultFunc)
+	g.ForEach(func(loc L.Location, value T) {
+		if bounds.Has(loc) {
+			out.Set(loc, value)
+		}
+	})
+	return out
+}
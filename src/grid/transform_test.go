@@ -0,0 +1,125 @@
+package grid
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/wthys/advent-of-code-2024/location"
+)
+
+var letters = []string{
+	"abc",
+	"def",
+}
+
+func rendered(t *testing.T, g Reader[rune]) string {
+	t.Helper()
+
+	r := Renderer[rune]{
+		Cell: func(_ location.Location, v rune, err error) string {
+			if err != nil {
+				return "."
+			}
+			return string(v)
+		},
+	}
+
+	var sb strings.Builder
+	if err := r.Render(&sb, g); err != nil {
+		t.Fatal(err)
+	}
+	return sb.String()
+}
+
+func sameGrid(t *testing.T, got Reader[rune], want Reader[rune]) {
+	t.Helper()
+
+	if got.Len() != want.Len() {
+		t.Fatalf("got %v values, want %v", got.Len(), want.Len())
+	}
+	want.ForEach(func(loc location.Location, value rune) {
+		if v, err := got.Get(loc); v != value || err != nil {
+			t.Fatalf("Get(%v) = %c, %v, want %c, %v", loc, v, err, value, nil)
+		}
+	})
+}
+
+func TestTransformations(t *testing.T) {
+	sparse, _ := FromLines(letters, Runes)
+	sparse = sparse.Translate(location.New(2, -1))
+	dense, _ := DenseFromLines(letters, Runes)
+
+	for _, tc := range []struct {
+		name   string
+		sparse *Grid[rune]
+		dense  *Dense[rune]
+		want   string
+	}{
+		{"Rotate90", sparse.Rotate90(), dense.Rotate90(), "da\neb\nfc\n"},
+		{"FlipH", sparse.FlipH(), dense.FlipH(), "cba\nfed\n"},
+		{"FlipV", sparse.FlipV(), dense.FlipV(), "def\nabc\n"},
+		{"Transpose", sparse.Transpose(), dense.Transpose(), "ad\nbe\ncf\n"},
+		{"Crop", sparse.Crop(Bounds{3, 4, -1, 0}), dense.Crop(Bounds{1, 2, 0, 1}), "bc\nef\n"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := rendered(t, tc.sparse); got != tc.want {
+				t.Errorf("sparse %v =\n%s\nwant\n%s", tc.name, got, tc.want)
+			}
+			if got := rendered(t, tc.dense); got != tc.want {
+				t.Errorf("dense %v =\n%s\nwant\n%s", tc.name, got, tc.want)
+			}
+		})
+	}
+
+	if bounds, _ := sparse.Rotate90().Bounds(); bounds != (Bounds{2, 3, -1, 1}) {
+		t.Fatalf("Rotate90().Bounds() = %v, want the top left corner kept at (2,-1)", bounds)
+	}
+}
+
+func TestTransformationsRoundTrip(t *testing.T) {
+	sparse, _ := FromLines([]string{"ab.", "c.d", "efg", "..h"}, Runes, Background("."), SkipBackground())
+	dense, _ := DenseFromLines([]string{"abcd", "efgh", "ijkl"}, Runes)
+	offset := location.New(-3, 7)
+
+	sameGrid(t, sparse.Rotate90().Rotate90().Rotate90().Rotate90(), sparse)
+	sameGrid(t, sparse.FlipH().FlipH(), sparse)
+	sameGrid(t, sparse.FlipV().FlipV(), sparse)
+	sameGrid(t, sparse.Transpose().Transpose(), sparse)
+	sameGrid(t, sparse.Translate(offset).Translate(location.New(3, -7)), sparse)
+	sameGrid(t, sparse.Transpose().FlipH(), sparse.Rotate90())
+	sameGrid(t, sparse.FlipH().FlipV(), sparse.Rotate90().Rotate90())
+
+	sameGrid(t, dense.Rotate90().Rotate90().Rotate90().Rotate90(), dense)
+	sameGrid(t, dense.FlipH().FlipH(), dense)
+	sameGrid(t, dense.FlipV().FlipV(), dense)
+	sameGrid(t, dense.Transpose().Transpose(), dense)
+	sameGrid(t, dense.Translate(offset).Translate(location.New(3, -7)), dense)
+	sameGrid(t, dense.Transpose().FlipH(), dense.Rotate90())
+	sameGrid(t, dense.FlipH().FlipV(), dense.Rotate90().Rotate90())
+
+	if bounds, _ := dense.Translate(offset).Bounds(); bounds != (Bounds{-3, 0, 7, 9}) {
+		t.Fatalf("Translate(%v).Bounds() = %v, want %v", offset, bounds, Bounds{-3, 0, 7, 9})
+	}
+}
+
+func TestWindow(t *testing.T) {
+	g, _ := FromLines(letters, Runes)
+	view := Window(g, Bounds{1, 5, 1, 1})
+
+	if got, want := rendered(t, view), "ef...\n"; got != want {
+		t.Fatalf("rendered window = %q, want %q", got, want)
+	}
+
+	if view.Len() != 2 {
+		t.Fatalf("view.Len() = %v, want %v", view.Len(), 2)
+	}
+
+	if _, err := view.Get(location.New(0, 0)); err == nil {
+		t.Fatalf("view.Get() outside of the window gave no error")
+	}
+
+	g.Set(location.New(2, 1), 'x')
+	if v, _ := view.Get(location.New(2, 1)); v != 'x' {
+		t.Fatalf("view.Get() after changing the grid = %c, want %c", v, 'x')
+	}
+}
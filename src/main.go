@@ -6,139 +6,1310 @@ import (
     "os"
     "fmt"
     "bufio"
+    "bytes"
+    "io"
+    "log/slog"
+    "path/filepath"
+    "runtime"
+    "slices"
+    "strconv"
+    "strings"
+    "sync"
+    "text/tabwriter"
 
-    log "github.com/obalunenko/logger"
     "github.com/urfave/cli/v2"
 
+    "github.com/wthys/advent-of-code-2024/config"
+    "github.com/wthys/advent-of-code-2024/scaffold"
     "github.com/wthys/advent-of-code-2024/solver"
     _ "github.com/wthys/advent-of-code-2024/solutions"
 )
 
 
+// fatal logs `err` and exits, for errors that happen before or outside of the
+// logger configured by the log flags.
+func fatal(msg string, err error) {
+    solver.NewLogger(os.Stderr, solver.DefaultLogLevel).Error(msg, "error", err)
+    os.Exit(1)
+}
+
+
 func onExit(ctx context.Context) cli.AfterFunc {
     return func(c *cli.Context) error {
         return nil
     }
 }
 
-
-func notFound(ctx context.Context) cli.CommandNotFoundFunc {
-    return func(c *cli.Context, command string) {
-        if _, err := fmt.Fprintf(
-            c.App.Writer,
-            "Command [%s] not supported.\n Try --help flag to see how to use it\n",
-            command,
-        ); err != nil {
-            log.WithError(ctx, err).Fatal("Failed to print not found message")
-        }
+
+func notFound(ctx context.Context) cli.CommandNotFoundFunc {
+    return func(c *cli.Context, command string) {
+        if _, err := fmt.Fprintf(
+            c.App.Writer,
+            "Command [%s] not supported.\n Try --help flag to see how to use it\n",
+            command,
+        ); err != nil {
+            fatal("Failed to print not found message", err)
+        }
+    }
+}
+
+
+func cmdRunFlags() []cli.Flag {
+    var flags []cli.Flag
+
+    elapsed := cli.BoolFlag{
+        Name: "elapsed",
+        Aliases: []string{"e"},
+        Usage: "Shows elapsed time metric",
+        Required: false,
+        HasBeenSet: false,
+        EnvVars: []string{"ELAPSED"},
+    }
+
+    debug := cli.BoolFlag{
+        Name: "debug",
+        Aliases: []string{"d"},
+        Usage: "Shows debug output, same as --log-level debug",
+        Required: false,
+        HasBeenSet: false,
+        EnvVars: []string{"DEBUG"},
+    }
+
+    session := cli.StringFlag{
+        Name: "session",
+        Aliases: []string{"s"},
+        Usage: "AOC Auth session token for getting inputs missing from the cache",
+        EnvVars: []string{"AOC_SESSION"},
+        Required: false,
+        HasBeenSet: false,
+    }
+
+    all := cli.BoolFlag{
+        Name: "all",
+        Aliases: []string{"a"},
+        Usage: "Runs every registered solution and prints a combined table",
+        Required: false,
+        HasBeenSet: false,
+    }
+
+    inputDir := cli.StringFlag{
+        Name: "input-dir",
+        Usage: "Directory with <day>.txt inputs to use with --all instead of the cache",
+        Required: false,
+        HasBeenSet: false,
+    }
+
+    verify := cli.BoolFlag{
+        Name: "verify",
+        Usage: "Compares the results against the known answers, failing on mismatches",
+        Required: false,
+        HasBeenSet: false,
+    }
+
+    record := cli.BoolFlag{
+        Name: "record",
+        Usage: "Stores the solved results as known answers",
+        Required: false,
+        HasBeenSet: false,
+    }
+
+    answers := cli.StringFlag{
+        Name: "answers",
+        Usage: "JSON file with the known answers",
+        Value: "answers.json",
+        EnvVars: []string{"AOC_ANSWERS"},
+        Required: false,
+        HasBeenSet: false,
+    }
+
+    format := cli.StringFlag{
+        Name: "format",
+        Aliases: []string{"f"},
+        Usage: "Output format: text, json, csv or markdown",
+        Value: string(solver.FormatText),
+        EnvVars: []string{"AOC_FORMAT"},
+        Required: false,
+        HasBeenSet: false,
+    }
+
+    timeout := cli.DurationFlag{
+        Name: "timeout",
+        Usage: "Gives up on a part after this long (e.g. 30s), 0 waits forever",
+        EnvVars: []string{"AOC_TIMEOUT"},
+        Required: false,
+        HasBeenSet: false,
+    }
+
+    parallel := cli.BoolFlag{
+        Name: "parallel",
+        Aliases: []string{"p"},
+        Usage: "Runs both parts, and with --all the days, concurrently",
+        Required: false,
+        HasBeenSet: false,
+    }
+
+    jobs := cli.IntFlag{
+        Name: "jobs",
+        Aliases: []string{"j"},
+        Usage: "Number of days to run at the same time with --parallel",
+        Value: runtime.NumCPU(),
+        Required: false,
+        HasBeenSet: false,
+    }
+
+    flags = append(flags, &elapsed, &debug, &session, &all, &inputDir, &format, &timeout)
+    flags = append(flags, &parallel, &jobs)
+    flags = append(flags, &verify, &record, &answers)
+    flags = append(flags, yearFlag())
+    flags = append(flags, inputFlags()...)
+    flags = append(flags, cacheFlags()...)
+    flags = append(flags, clientFlags()...)
+    flags = append(flags, logFlags()...)
+    flags = append(flags, paramFlags()...)
+
+    return flags
+}
+
+
+func yearFlag() cli.Flag {
+    return &cli.StringFlag{
+        Name: "year",
+        Aliases: []string{"y"},
+        Usage: "Year of the puzzles",
+        Value: solver.DefaultYear,
+        EnvVars: []string{"AOC_YEAR"},
+        Required: false,
+        HasBeenSet: false,
+    }
+}
+
+// yearFile returns the value of the file flag `name`. Unless it is set
+// explicitly, the file name gets the year as a suffix for years other than
+// `solver.DefaultYear`, e.g. answers-2023.json.
+func yearFile(c *cli.Context, name string) string {
+    path := c.String(name)
+    year := c.String("year")
+    if c.IsSet(name) || year == solver.DefaultYear {
+        return path
+    }
+
+    ext := filepath.Ext(path)
+    return strings.TrimSuffix(path, ext) + "-" + year + ext
+}
+
+
+func cacheFlags() []cli.Flag {
+    var flags []cli.Flag
+
+    dir := cli.StringFlag{
+        Name: "cache-dir",
+        Usage: "Directory for cached puzzle inputs (default $XDG_CACHE_HOME/aoc2024)",
+        EnvVars: []string{"AOC_CACHE_DIR"},
+        Required: false,
+        HasBeenSet: false,
+    }
+
+    refresh := cli.BoolFlag{
+        Name: "refresh",
+        Usage: "Downloads the input again and overwrites the cached copy",
+        Required: false,
+        HasBeenSet: false,
+    }
+
+    noCache := cli.BoolFlag{
+        Name: "no-cache",
+        Usage: "Downloads the input without reading or writing the cache",
+        Required: false,
+        HasBeenSet: false,
+    }
+
+    flags = append(flags, &dir, &refresh, &noCache)
+
+    return flags
+}
+
+func clientFlags() []cli.Flag {
+    var flags []cli.Flag
+
+    defaults := solver.DefaultClientConfig()
+
+    timeout := cli.DurationFlag{
+        Name: "http-timeout",
+        Usage: "Gives up on a request to the puzzle site after this long",
+        Value: defaults.Timeout,
+        EnvVars: []string{"AOC_HTTP_TIMEOUT"},
+        Required: false,
+        HasBeenSet: false,
+    }
+
+    retries := cli.IntFlag{
+        Name: "http-retries",
+        Usage: "Number of retries after a server error, with a doubling wait",
+        Value: defaults.Retries,
+        EnvVars: []string{"AOC_HTTP_RETRIES"},
+        Required: false,
+        HasBeenSet: false,
+    }
+
+    throttle := cli.DurationFlag{
+        Name: "throttle",
+        Usage: "Minimum time between requests to the puzzle site",
+        Value: defaults.Throttle,
+        EnvVars: []string{"AOC_THROTTLE"},
+        Required: false,
+        HasBeenSet: false,
+    }
+
+    agent := cli.StringFlag{
+        Name: "user-agent",
+        Usage: "User agent for requests to the puzzle site, preferably with contact information",
+        Value: defaults.UserAgent,
+        EnvVars: []string{"AOC_USER_AGENT"},
+        Required: false,
+        HasBeenSet: false,
+    }
+
+    flags = append(flags, &timeout, &retries, &throttle, &agent)
+
+    return flags
+}
+
+// configureClient applies the client flags to the client talking to the
+// puzzle site.
+func configureClient(c *cli.Context) {
+    cfg := solver.DefaultClientConfig()
+    cfg.Timeout = c.Duration("http-timeout")
+    cfg.Retries = c.Int("http-retries")
+    cfg.Throttle = c.Duration("throttle")
+    cfg.UserAgent = c.String("user-agent")
+
+    solver.Site.Configure(cfg)
+}
+
+func logFlags() []cli.Flag {
+    var flags []cli.Flag
+
+    level := cli.StringFlag{
+        Name: "log-level",
+        Usage: "Minimum level of log messages: debug, info, warn or error",
+        Value: solver.DefaultLogLevel.String(),
+        EnvVars: []string{"AOC_LOG_LEVEL"},
+        Required: false,
+        HasBeenSet: false,
+    }
+
+    file := cli.StringFlag{
+        Name: "log-file",
+        Usage: "Appends log messages to this file instead of writing them to stderr",
+        EnvVars: []string{"AOC_LOG_FILE"},
+        Required: false,
+        HasBeenSet: false,
+    }
+
+    flags = append(flags, &level, &file)
+
+    return flags
+}
+
+
+func inputFlags() []cli.Flag {
+    var flags []cli.Flag
+
+    input := cli.StringFlag{
+        Name: "input",
+        Aliases: []string{"i"},
+        Usage: "Reads the puzzle input from this file, - for stdin",
+        Required: false,
+        HasBeenSet: false,
+    }
+
+    example := cli.BoolFlag{
+        Name: "example",
+        Aliases: []string{"x"},
+        Usage: "Uses the example instead of the puzzle input",
+        Required: false,
+        HasBeenSet: false,
+    }
+
+    name := cli.StringFlag{
+        Name: "example-name",
+        Usage: "Uses the example with this name, implies --example",
+        Required: false,
+        HasBeenSet: false,
+    }
+
+    dir := cli.StringFlag{
+        Name: "examples-dir",
+        Usage: "Directory holding the examples",
+        Value: "examples",
+        EnvVars: []string{"AOC_EXAMPLES_DIR"},
+        Required: false,
+        HasBeenSet: false,
+    }
+
+    flags = append(flags, &input, &example, &name, &dir)
+
+    return flags
+}
+
+
+func paramFlags() []cli.Flag {
+    var flags []cli.Flag
+
+    param := cli.StringSliceFlag{
+        Name: "param",
+        Usage: "Sets a solver parameter as name=value, e.g. width=11; can be repeated",
+        Required: false,
+        HasBeenSet: false,
+    }
+
+    flags = append(flags, &param)
+
+    return flags
+}
+
+
+// withParams stores the parameters given with --param in the context under
+// "params".
+func withParams(ctx context.Context, c *cli.Context) (context.Context, error) {
+    params, err := solver.ParseParams(c.StringSlice("param"))
+    if err != nil {
+        return ctx, err
+    }
+
+    return context.WithValue(ctx, "params", params), nil
+}
+
+
+// withLogger stores the logger configured by the log flags in the context
+// under "logger". The returned function closes the log file, if any.
+func withLogger(ctx context.Context, c *cli.Context) (context.Context, func(), error) {
+    level, err := solver.ParseLogLevel(c.String("log-level"))
+    if err != nil {
+        return ctx, nil, err
+    }
+
+    if c.Bool("debug") && !c.IsSet("log-level") {
+        level = slog.LevelDebug
+    }
+
+    var w io.Writer = os.Stderr
+    closer := func() {}
+
+    if path := c.String("log-file"); path != "" {
+        file, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+        if err != nil {
+            return ctx, nil, fmt.Errorf("open log file: %w", err)
+        }
+        w = file
+        closer = func() { file.Close() }
+    }
+
+    return context.WithValue(ctx, "logger", solver.NewLogger(w, level)), closer, nil
+}
+
+// inputCache opens the cache chosen with the cache flags and configures the
+// client for the downloads that may follow.
+func inputCache(c *cli.Context) (solver.InputCache, solver.CacheMode, error) {
+    configureClient(c)
+
+    cache, err := solver.NewInputCache(c.String("cache-dir"))
+    if err != nil {
+        return solver.InputCache{}, solver.CacheUse, err
+    }
+
+    switch {
+    case c.Bool("no-cache"):
+        return cache, solver.CacheBypass, nil
+    case c.Bool("refresh"):
+        return cache, solver.CacheRefresh, nil
+    default:
+        return cache, solver.CacheUse, nil
+    }
+}
+
+func stdinIsPiped() bool {
+    info, err := os.Stdin.Stat()
+    if err != nil {
+        return false
+    }
+    return info.Mode() & os.ModeCharDevice == 0
+}
+
+// readInput finds the input for `day`, trying in order: the --input file, the
+// example selected with --example or --example-name, the input directory or
+// cache, and finally stdin when `stdin` is set and something is piped in. The
+// returned context carries the parameters of the example, if any.
+func readInput(ctx context.Context, c *cli.Context, day string, stdin bool) (context.Context, io.Reader, error) {
+    if path := c.String("input"); path != "" {
+        if path == "-" {
+            return ctx, bufio.NewReader(os.Stdin), nil
+        }
+
+        input, err := os.ReadFile(path)
+        if err != nil {
+            return ctx, nil, fmt.Errorf("read input: %w", err)
+        }
+        return ctx, bytes.NewReader(input), nil
+    }
+
+    if c.Bool("example") || c.IsSet("example-name") {
+        return readExample(ctx, c, day)
+    }
+
+    input, err := readDayInput(ctx, c, day)
+    if errors.Is(err, solver.ErrNotCached) && stdin && stdinIsPiped() {
+        return ctx, bufio.NewReader(os.Stdin), nil
+    }
+    if errors.Is(err, solver.ErrNotCached) {
+        return ctx, nil, fmt.Errorf("%w, use --input, pipe it in or provide a session token", err)
+    }
+    return ctx, input, err
+}
+
+// readExample reads an example from the --examples-dir directory. Its
+// parameters are added to those in `ctx`, which take precedence.
+func readExample(ctx context.Context, c *cli.Context, day string) (context.Context, io.Reader, error) {
+    dir := solver.YearDir(c.String("examples-dir"), c.String("year"))
+    ex, err := solver.LoadExample(dir, day, c.String("example-name"))
+    if err != nil {
+        return ctx, nil, err
+    }
+
+    input, err := os.ReadFile(ex.Path)
+    if err != nil {
+        return ctx, nil, fmt.Errorf("read example: %w", err)
+    }
+
+    params := ex.Params.Merge(solver.ParamsFrom(ctx))
+    return context.WithValue(ctx, "params", params), bytes.NewReader(input), nil
+}
+
+// readDayInput reads the input for `day` from the --input-dir directory, or
+// from the input cache when no directory is given.
+func readDayInput(ctx context.Context, c *cli.Context, day string) (io.Reader, error) {
+    if dir := c.String("input-dir"); dir != "" {
+        input, err := solver.InputCache{Dir: dir}.Load(day)
+        if err != nil {
+            return nil, err
+        }
+        return bytes.NewReader(input), nil
+    }
+
+    cache, mode, err := inputCache(c)
+    if err != nil {
+        return nil, err
+    }
+
+    input, err := solver.GetCachedInput(ctx, cache, mode, c.String("year"), day, c.String("session"))
+    if err != nil {
+        return nil, err
+    }
+
+    return bytes.NewReader(input), nil
+}
+
+func solveDay(ctx context.Context, c *cli.Context, s solver.Solver) (solver.Result, error) {
+    ctx, input, err := readInput(ctx, c, s.Day(), false)
+    if err != nil {
+        return solver.Result{}, err
+    }
+
+    return solver.Solve(s, input, ctx)
+}
+
+func runAll(ctx context.Context, c *cli.Context, format solver.Format) error {
+    all := solver.SolversOf(c.String("year"))
+
+    results := make([]solver.Result, len(all))
+    errs := make([]error, len(all))
+
+    jobs := 1
+    if c.Bool("parallel") {
+        jobs = max(c.Int("jobs"), 1)
+    }
+
+    todo := make(chan int)
+    var wg sync.WaitGroup
+    for range jobs {
+        wg.Add(1)
+        go func() {
+            defer wg.Done()
+            for idx := range todo {
+                s := all[idx]
+                res, err := solveDay(ctx, c, s)
+                if err != nil {
+                    errs[idx] = fmt.Errorf("day %s: %w", s.Day(), err)
+                    if res.Name == "" {
+                        res = solver.FailedResult(s.Day(), err)
+                    }
+                }
+                results[idx] = res
+            }
+        }()
+    }
+
+    for idx := range all {
+        todo <- idx
+    }
+    close(todo)
+    wg.Wait()
+
+    if err := solver.WriteResults(os.Stdout, format, results); err != nil {
+        return err
+    }
+
+    errs = append(errs, checkAnswers(c, results))
+
+    return errors.Join(errs...)
+}
+
+// checkAnswers records or verifies the known answers for `results`, depending
+// on the --record and --verify flags.
+func checkAnswers(c *cli.Context, results []solver.Result) error {
+    if !c.Bool("verify") && !c.Bool("record") {
+        return nil
+    }
+
+    path := yearFile(c, "answers")
+
+    answers, err := solver.LoadAnswers(path)
+    if err != nil {
+        return err
+    }
+
+    if c.Bool("record") {
+        for _, r := range results {
+            answers.Record(r)
+        }
+        return solver.SaveAnswers(path, answers)
+    }
+
+    return answers.VerifyAll(results)
+}
+
+func cmdRun(ctx context.Context) cli.ActionFunc {
+    return func (c *cli.Context) error {
+        format, err := solver.ParseFormat(c.String("format"))
+        if err != nil {
+            return err
+        }
+
+        // machine-readable formats always carry the elapsed times
+        if c.Bool("elapsed") || format != solver.FormatText {
+            ctx = context.WithValue(ctx, "elapsed", true)
+        }
+
+        ctx, closeLog, err := withLogger(ctx, c)
+        if err != nil {
+            return err
+        }
+        defer closeLog()
+
+        ctx, err = withParams(ctx, c)
+        if err != nil {
+            return err
+        }
+
+        if c.Bool("parallel") {
+            ctx = context.WithValue(ctx, "parallel", true)
+        }
+
+        if timeout := c.Duration("timeout"); timeout > 0 {
+            ctx = context.WithValue(ctx, "timeout", timeout)
+        }
+
+        if c.Bool("all") {
+            if c.IsSet("input") {
+                return errors.New("--input cannot be combined with --all")
+            }
+            return runAll(ctx, c, format)
+        }
+
+        s, err := solver.GetSolver(c.String("year"), c.Args().First())
+        if err != nil {
+            return err
+        }
+
+        ctx, input, err := readInput(ctx, c, s.Day(), true)
+        if err != nil {
+            return err
+        }
+
+        res, err := solver.Solve(s, input, ctx)
+
+        if res.Name == "" {
+            return err
+        }
+
+        if err := solver.WriteResults(os.Stdout, format, []solver.Result{res}); err != nil {
+            return err
+        }
+
+        // the part that did succeed is still recorded or verified
+        return errors.Join(err, checkAnswers(c, []solver.Result{res}))
+    }
+}
+
+
+func cmdInputFlags() []cli.Flag {
+    var flags []cli.Flag
+
+    session := cli.StringFlag{
+        Name: "session",
+        Aliases: []string{"s"},
+        Usage: "AOC Auth session token for getting inputs directly",
+        EnvVars: []string{"AOC_SESSION"},
+        Required: false,
+        HasBeenSet: false,
+    }
+
+    flags = append(flags, &session, yearFlag())
+    flags = append(flags, cacheFlags()...)
+    flags = append(flags, clientFlags()...)
+
+    return flags
+}
+
+func cmdInput(ctx context.Context) cli.ActionFunc {
+    return func (c *cli.Context) error {
+
+        var sess = c.String("session")
+        if sess == "" {
+            sess = c.String("s")
+        }
+
+        ctx = context.WithValue(ctx, "session", sess)
+
+        day := c.Args().First()
+        if day == "" {
+            return errors.New("no puzzle provided")
+        }
+
+        cache, mode, err := inputCache(c)
+        if err != nil {
+            return err
+        }
+
+        input, err := solver.GetCachedInput(ctx, cache, mode, c.String("year"), day, sess)
+
+        if errors.Is(err, solver.ErrNotCached) {
+            return fmt.Errorf("no session token provided: %w", err)
+        }
+        if err != nil {
+            return err
+        }
+
+        fmt.Print(string(input[:]))
+
+        return nil
+    }
+}
+
+
+func cmdSubmitFlags() []cli.Flag {
+    var flags []cli.Flag
+
+    session := cli.StringFlag{
+        Name: "session",
+        Aliases: []string{"s"},
+        Usage: "AOC Auth session token for submitting answers",
+        EnvVars: []string{"AOC_SESSION"},
+        Required: false,
+        HasBeenSet: false,
+    }
+
+    answers := cli.StringFlag{
+        Name: "answers",
+        Usage: "JSON file to record correct answers in",
+        Value: "answers.json",
+        EnvVars: []string{"AOC_ANSWERS"},
+        Required: false,
+        HasBeenSet: false,
+    }
+
+    flags = append(flags, &session, &answers, yearFlag())
+    flags = append(flags, cacheFlags()...)
+    flags = append(flags, clientFlags()...)
+
+    return flags
+}
+
+// computeAnswer solves `part` of day `day` to get an answer to submit.
+func computeAnswer(ctx context.Context, c *cli.Context, day string, part int) (solver.Answer, error) {
+    s, err := solver.GetSolver(c.String("year"), day)
+    if err != nil {
+        return solver.NoAnswer, err
+    }
+
+    ctx, input, err := readInput(ctx, c, s.Day(), true)
+    if err != nil {
+        return solver.NoAnswer, err
+    }
+
+    res, err := solver.Solve(s, input, ctx)
+    if err != nil {
+        return solver.NoAnswer, err
+    }
+
+    answer := res.TypedAnswer(part)
+
+    if answer.String() == solver.Unsolved {
+        return solver.NoAnswer, fmt.Errorf("day %s part %d: %w", day, part, solver.ErrNotImplemented)
+    }
+
+    return answer, nil
+}
+
+func cmdSubmit(ctx context.Context) cli.ActionFunc {
+    return func (c *cli.Context) error {
+        day := c.Args().Get(0)
+        if day == "" {
+            return errors.New("no puzzle provided")
+        }
+
+        part, err := strconv.Atoi(c.Args().Get(1))
+        if err != nil || (part != 1 && part != 2) {
+            return fmt.Errorf("invalid part %q, expected 1 or 2", c.Args().Get(1))
+        }
+
+        // not a required flag, as those ignore a session from the config file
+        if c.String("session") == "" {
+            return errors.New("no session token provided, use --session, AOC_SESSION or the config file")
+        }
+
+        answer := solver.UntypedAnswer(c.Args().Get(2))
+        if c.Args().Get(2) == "" {
+            answer, err = computeAnswer(ctx, c, day, part)
+            if err != nil {
+                return err
+            }
+        }
+
+        cache, _, err := inputCache(c)
+        if err != nil {
+            return err
+        }
+
+        history, err := solver.LoadSubmissionLog(cache.ForYear(c.String("year")).SubmissionsPath())
+        if err != nil {
+            return err
+        }
+
+        if err := history.Check(day, part, answer.String()); err != nil {
+            return err
+        }
+
+        sub, err := solver.SubmitAnswer(ctx, c.String("year"), day, part, answer.String(), c.String("session"))
+        if err != nil {
+            return err
+        }
+
+        history.Add(sub)
+        if err := history.Save(); err != nil {
+            return err
+        }
+
+        fmt.Println(sub)
+
+        switch sub.Outcome {
+        case solver.OutcomeCorrect:
+            return recordAnswer(yearFile(c, "answers"), day, part, answer)
+        case solver.OutcomeAlreadySolved:
+            return nil
+        default:
+            return fmt.Errorf("answer not accepted: %s", sub.Message)
+        }
+    }
+}
+
+// recordAnswer stores a single accepted answer in the known answers file.
+func recordAnswer(path string, day string, part int, answer solver.Answer) error {
+    answers, err := solver.LoadAnswers(path)
+    if err != nil {
+        return err
     }
+
+    res := solver.Result{Name: day, Answers: []solver.Answer{solver.NoAnswer, solver.NoAnswer}}
+    res.Answers[part-1] = answer
+    answers.Record(res)
+
+    return solver.SaveAnswers(path, answers)
 }
 
 
-func cmdRunFlags() []cli.Flag {
+func cmdBenchFlags() []cli.Flag {
     var flags []cli.Flag
 
-    elapsed := cli.BoolFlag{
-        Name: "elapsed",
-        Aliases: []string{"e"},
-        Usage: "Shows elapsed time metric",
+    count := cli.IntFlag{
+        Name: "count",
+        Aliases: []string{"n"},
+        Usage: "Number of runs per part",
+        Value: 10,
         Required: false,
         HasBeenSet: false,
-        EnvVars: []string{"ELAPSED"},
     }
 
-    debug := cli.BoolFlag{
-        Name: "debug",
-        Aliases: []string{"d"},
-        Usage: "Shows debug output",
+    budget := cli.DurationFlag{
+        Name: "budget",
+        Aliases: []string{"t"},
+        Usage: "Repeats each part for this long instead of a fixed number of runs",
+        Required: false,
+        HasBeenSet: false,
+    }
+
+    baseline := cli.StringFlag{
+        Name: "baseline",
+        Usage: "JSON file with the benchmark baseline",
+        Value: "bench.json",
+        EnvVars: []string{"AOC_BASELINE"},
+        Required: false,
+        HasBeenSet: false,
+    }
+
+    save := cli.BoolFlag{
+        Name: "save",
+        Usage: "Stores the results in the baseline",
+        Required: false,
+        HasBeenSet: false,
+    }
+
+    threshold := cli.Float64Flag{
+        Name: "threshold",
+        Usage: "Allowed slowdown of the median compared to the baseline, in percent",
+        Value: 10,
+        Required: false,
+        HasBeenSet: false,
+    }
+
+    session := cli.StringFlag{
+        Name: "session",
+        Aliases: []string{"s"},
+        Usage: "AOC Auth session token for getting inputs missing from the cache",
+        EnvVars: []string{"AOC_SESSION"},
+        Required: false,
+        HasBeenSet: false,
+    }
+
+    inputDir := cli.StringFlag{
+        Name: "input-dir",
+        Usage: "Directory with <day>.txt inputs to use instead of the cache",
         Required: false,
         HasBeenSet: false,
-        EnvVars: []string{"DEBUG"},
     }
 
-    flags = append(flags, &elapsed, &debug)
+    flags = append(flags, &count, &budget, &baseline, &save, &threshold, &session, &inputDir)
+    flags = append(flags, yearFlag())
+    flags = append(flags, inputFlags()...)
+    flags = append(flags, cacheFlags()...)
+    flags = append(flags, clientFlags()...)
+    flags = append(flags, logFlags()...)
+    flags = append(flags, paramFlags()...)
 
     return flags
 }
 
-func cmdRun(ctx context.Context) cli.ActionFunc {
+func cmdBench(ctx context.Context) cli.ActionFunc {
     return func (c *cli.Context) error {
-        if c.Bool("elapsed") {
-            ctx = context.WithValue(ctx, "elapsed", true)
+        s, err := solver.GetSolver(c.String("year"), c.Args().First())
+        if err != nil {
+            return err
         }
 
-        if c.Bool("debug") {
-            ctx = context.WithValue(ctx, "debug", true)
+        ctx, closeLog, err := withLogger(ctx, c)
+        if err != nil {
+            return err
         }
+        defer closeLog()
 
-        s, err := solver.GetSolver(c.Args().First())
+        ctx, err = withParams(ctx, c)
         if err != nil {
             return err
         }
 
-        res, err := solver.Solve(s, bufio.NewReader(os.Stdin), ctx)
+        ctx, input, err := readInput(ctx, c, s.Day(), true)
+        if err != nil {
+            return err
+        }
 
+        lines, err := solver.ReadLines(input)
         if err != nil {
             return err
         }
 
-        fmt.Println(res)
+        bo := solver.BenchOptions{
+            Runs: c.Int("count"),
+            Budget: c.Duration("budget"),
+            Params: solver.ParamsFrom(ctx),
+            Logger: solver.LoggerFrom(ctx),
+        }
 
-        return nil
+        res, err := solver.Bench(s, lines, bo)
+        if err != nil {
+            return err
+        }
+
+        path := yearFile(c, "baseline")
+
+        baseline, err := solver.LoadBaseline(path)
+        if err != nil {
+            return err
+        }
+
+        if err := solver.WriteBench(os.Stdout, res, baseline); err != nil {
+            return err
+        }
+
+        if c.Bool("save") {
+            baseline[res.Day] = res
+            return solver.SaveBaseline(path, baseline)
+        }
+
+        regressions := []error{}
+        for _, r := range baseline.Compare(res, c.Float64("threshold") / 100) {
+            regressions = append(regressions, r)
+        }
+
+        return errors.Join(regressions...)
     }
 }
 
 
-func cmdInputFlags() []cli.Flag {
+func cmdDescribeFlags() []cli.Flag {
     var flags []cli.Flag
 
     session := cli.StringFlag{
         Name: "session",
         Aliases: []string{"s"},
-        Usage: "AOC Auth session token for getting inputs directly",
+        Usage: "AOC Auth session token, needed to see the second part",
         EnvVars: []string{"AOC_SESSION"},
-        Required: true,
+        Required: false,
+        HasBeenSet: false,
+    }
+
+    format := cli.StringFlag{
+        Name: "format",
+        Aliases: []string{"f"},
+        Usage: "Output format: text or markdown",
+        Value: string(solver.FormatText),
+        Required: false,
+        HasBeenSet: false,
+    }
+
+    width := cli.IntFlag{
+        Name: "width",
+        Usage: "Wraps text paragraphs at this many columns",
+        Value: 80,
+        Required: false,
+        HasBeenSet: false,
+    }
+
+    seed := cli.BoolFlag{
+        Name: "seed",
+        Usage: "Writes an example code block to the example file of the day, unless it has content",
+        Required: false,
+        HasBeenSet: false,
+    }
+
+    block := cli.IntFlag{
+        Name: "block",
+        Usage: "Number of the code block to seed the example with",
+        Value: 1,
+        Required: false,
+        HasBeenSet: false,
+    }
+
+    examplesDir := cli.StringFlag{
+        Name: "examples-dir",
+        Usage: "Directory holding the examples",
+        Value: "examples",
+        EnvVars: []string{"AOC_EXAMPLES_DIR"},
+        Required: false,
         HasBeenSet: false,
     }
 
-    flags = append(flags, &session)
+    flags = append(flags, &session, &format, &width, &seed, &block, &examplesDir, yearFlag())
+    flags = append(flags, cacheFlags()...)
+    flags = append(flags, clientFlags()...)
 
     return flags
 }
 
-func cmdInput(ctx context.Context) cli.ActionFunc {
+// seedExample writes code block `block` (1-based) of `desc` to the example
+// file of the day, leaving files that already have content alone.
+func seedExample(dir string, desc solver.Description, block int) (string, error) {
+    examples := desc.Examples()
+    if block < 1 || block > len(examples) {
+        return "", fmt.Errorf("no code block %d, the description has %d", block, len(examples))
+    }
+
+    path := filepath.Join(dir, fmt.Sprintf("day%s.txt", desc.Day))
+    if info, err := os.Stat(path); err == nil && info.Size() > 0 {
+        return "", fmt.Errorf("%s already has content", path)
+    }
+
+    if err := os.MkdirAll(dir, 0o755); err != nil {
+        return "", err
+    }
+
+    return path, os.WriteFile(path, []byte(examples[block-1]), 0o644)
+}
+
+func cmdDescribe(ctx context.Context) cli.ActionFunc {
     return func (c *cli.Context) error {
+        day := c.Args().First()
+        if day == "" {
+            return errors.New("no puzzle provided")
+        }
 
-        var sess = c.String("session")
-        if sess == "" {
-            sess = c.String("s")
+        format, err := solver.ParseFormat(c.String("format"))
+        if err != nil {
+            return err
+        }
+        if format != solver.FormatText && format != solver.FormatMarkdown {
+            return fmt.Errorf("describe only supports text and markdown, not %q", format)
+        }
+
+        cache, mode, err := inputCache(c)
+        if err != nil {
+            return err
+        }
+
+        desc, err := solver.GetCachedDescription(ctx, cache, mode, c.String("year"), day, c.String("session"))
+        if err != nil {
+            return err
+        }
+
+        if format == solver.FormatMarkdown {
+            err = desc.WriteMarkdown(os.Stdout)
+        } else {
+            err = desc.WriteText(os.Stdout, c.Int("width"))
+        }
+        if err != nil {
+            return err
+        }
 
-            if sess == "" {
-                return errors.New("no session token provided")
+        if c.Bool("seed") {
+            dir := solver.YearDir(c.String("examples-dir"), c.String("year"))
+            path, err := seedExample(dir, desc, c.Int("block"))
+            if err != nil {
+                return err
             }
+            fmt.Fprintf(os.Stderr, "seeded %s\n", path)
         }
 
-        ctx = context.WithValue(ctx, "session", sess)
+        return nil
+    }
+}
+
+
+func cmdNewFlags() []cli.Flag {
+    var flags []cli.Flag
+
+    srcDir := cli.StringFlag{
+        Name: "src-dir",
+        Usage: "Directory of the Go module holding the solutions package",
+        Value: "src",
+        EnvVars: []string{"AOC_SRC_DIR"},
+        Required: false,
+        HasBeenSet: false,
+    }
+
+    examplesDir := cli.StringFlag{
+        Name: "examples-dir",
+        Usage: "Directory holding the examples",
+        Value: "examples",
+        EnvVars: []string{"AOC_EXAMPLES_DIR"},
+        Required: false,
+        HasBeenSet: false,
+    }
+
+    flags = append(flags, &srcDir, &examplesDir, yearFlag())
 
+    return flags
+}
+
+func cmdNew(ctx context.Context) cli.ActionFunc {
+    return func (c *cli.Context) error {
         day := c.Args().First()
         if day == "" {
             return errors.New("no puzzle provided")
         }
 
-        input, err := solver.GetInput(ctx, day, sess)
+        layout := scaffold.Layout{
+            SrcDir: c.String("src-dir"),
+            ExamplesDir: c.String("examples-dir"),
+            Year: c.String("year"),
+        }
+
+        written, err := scaffold.New(layout, day)
+        for _, path := range written {
+            fmt.Println(path)
+        }
+
+        return err
+    }
+}
+
+
+func cmdLeaderboardFlags() []cli.Flag {
+    var flags []cli.Flag
+
+    session := cli.StringFlag{
+        Name: "session",
+        Aliases: []string{"s"},
+        Usage: "AOC Auth session token of a member of the leaderboard",
+        EnvVars: []string{"AOC_SESSION"},
+        Required: false,
+        HasBeenSet: false,
+    }
+
+    day := cli.IntFlag{
+        Name: "day",
+        Aliases: []string{"d"},
+        Usage: "Shows when each member solved the parts of this day",
+        Required: false,
+        HasBeenSet: false,
+    }
+
+    flags = append(flags, &session, &day, yearFlag())
+    flags = append(flags, cacheFlags()...)
+    flags = append(flags, clientFlags()...)
+
+    return flags
+}
+
+func cmdLeaderboard(ctx context.Context) cli.ActionFunc {
+    return func (c *cli.Context) error {
+        id := c.Args().First()
+        if id == "" {
+            id = os.Getenv("AOC_LEADERBOARD")
+        }
+        if id == "" {
+            return errors.New("no leaderboard provided")
+        }
+
+        cache, mode, err := inputCache(c)
+        if err != nil {
+            return err
+        }
 
+        lb, err := solver.GetCachedLeaderboard(ctx, cache, mode, c.String("year"), id, c.String("session"))
         if err != nil {
             return err
         }
 
-        fmt.Print(string(input[:]))
+        if c.IsSet("day") {
+            return solver.WriteLeaderboardDay(os.Stdout, lb, c.Int("day"))
+        }
+        return solver.WriteLeaderboard(os.Stdout, lb)
+    }
+}
 
-        return nil
+
+// applyConfig makes the values of the config file the defaults of the flags
+// they belong to, so that flags and environment variables still win.
+func applyConfig(cmds []*cli.Command, conf config.Config) {
+    for _, cmd := range cmds {
+        for _, flag := range cmd.Flags {
+            sf, ok := flag.(*cli.StringFlag)
+            if !ok {
+                continue
+            }
+
+            setting, ok := settingOf(cmd.Name, sf.Name)
+            if !ok {
+                continue
+            }
+
+            value, ok := conf[setting.Key]
+            if !ok {
+                continue
+            }
+
+            sf.Value = value
+            if setting.Secret {
+                sf.DefaultText = config.Mask(value)
+            }
+        }
+
+        applyConfig(cmd.Subcommands, conf)
+    }
+}
+
+// settingOf returns the setting providing the default of flag `name` of
+// command `cmd`.
+func settingOf(cmd string, name string) (config.Setting, bool) {
+    for _, setting := range config.Settings {
+        if setting.Flag != name {
+            continue
+        }
+        if len(setting.Commands) > 0 && !slices.Contains(setting.Commands, cmd) {
+            continue
+        }
+        return setting, true
+    }
+    return config.Setting{}, false
+}
+
+// findFlag returns the first flag of the commands that `setting` applies to.
+func findFlag(cmds []*cli.Command, setting config.Setting) (*cli.StringFlag, bool) {
+    for _, cmd := range cmds {
+        if len(setting.Commands) > 0 && !slices.Contains(setting.Commands, cmd.Name) {
+            continue
+        }
+        for _, flag := range cmd.Flags {
+            if sf, ok := flag.(*cli.StringFlag); ok && sf.Name == setting.Flag {
+                return sf, true
+            }
+        }
     }
+    return nil, false
 }
 
+func cmdConfigShow(ctx context.Context, path string, conf config.Config) cli.ActionFunc {
+    return func (c *cli.Context) error {
+        status := ""
+        if _, err := os.Stat(path); err != nil {
+            status = " (not found)"
+        }
+        fmt.Printf("config file: %s%s\n\n", path, status)
+
+        tw := tabwriter.NewWriter(os.Stdout, 0, 8, 2, ' ', 0)
+        fmt.Fprintln(tw, "setting\tvalue\tsource")
+
+        for _, setting := range config.Settings {
+            flag, ok := findFlag(c.App.Commands, setting)
+            if !ok {
+                continue
+            }
+
+            value, source := flag.Value, "default"
+            if _, ok := conf[setting.Key]; ok {
+                source = "config"
+            }
+            for _, env := range flag.EnvVars {
+                if v, ok := os.LookupEnv(env); ok && v != "" {
+                    value, source = v, "env " + env
+                    break
+                }
+            }
+
+            if setting.Secret {
+                value = config.Mask(value)
+            }
+            if value == "" {
+                value = "-"
+            }
+
+            fmt.Fprintf(tw, "%s\t%s\t%s\n", setting.Key, value, source)
+        }
+
+        return tw.Flush()
+    }
+}
 
-func commands(ctx context.Context) []*cli.Command {
+func commands(ctx context.Context, confPath string, conf config.Config) []*cli.Command {
     return []*cli.Command{
         {
             Name: "run",
@@ -154,15 +1325,65 @@ func commands(ctx context.Context) []*cli.Command {
             Flags: cmdInputFlags(),
             SkipFlagParsing: false,
         },
+        {
+            Name: "bench",
+            Usage: `benchmark a specific solution`,
+            ArgsUsage: "<day>",
+            Action: cmdBench(ctx),
+            Flags: cmdBenchFlags(),
+            SkipFlagParsing: false,
+        },
+        {
+            Name: "submit",
+            Usage: `submit an answer for a specific day and part`,
+            ArgsUsage: "<day> <part> [answer]",
+            Action: cmdSubmit(ctx),
+            Flags: cmdSubmitFlags(),
+            SkipFlagParsing: false,
+        },
+        {
+            Name: "describe",
+            Usage: `show the description of a specific day`,
+            ArgsUsage: "<day>",
+            Action: cmdDescribe(ctx),
+            Flags: cmdDescribeFlags(),
+            SkipFlagParsing: false,
+        },
+        {
+            Name: "new",
+            Usage: `generate the package, test and example of a new day`,
+            ArgsUsage: "<day>",
+            Action: cmdNew(ctx),
+            Flags: cmdNewFlags(),
+            SkipFlagParsing: false,
+        },
+        {
+            Name: "leaderboard",
+            Usage: `show a private leaderboard`,
+            ArgsUsage: "<id>",
+            Action: cmdLeaderboard(ctx),
+            Flags: cmdLeaderboardFlags(),
+            SkipFlagParsing: false,
+        },
+        {
+            Name: "config",
+            Usage: `inspect the configuration`,
+            Subcommands: []*cli.Command{
+                {
+                    Name: "show",
+                    Usage: `show the effective settings and where they come from`,
+                    Action: cmdConfigShow(ctx, confPath, conf),
+                },
+            },
+        },
     }
 }
 
 var errExit = errors.New("exit is chosen")
 
-func main() {
-
-    ctx := context.Background()
-
+// newApp creates the command line app, with the settings of `conf` as the
+// defaults of the flags.
+func newApp(ctx context.Context, confPath string, conf config.Config) *cli.App {
     app := cli.NewApp()
     app.Name = "aoc2024"
     app.Description = "Solutions of puzzles for Advent of Code 2024" +
@@ -176,15 +1397,35 @@ func main() {
     }
 
     app.CommandNotFound = notFound(ctx)
-    app.Commands = commands(ctx)
+    app.Commands = commands(ctx, confPath, conf)
+    applyConfig(app.Commands, conf)
     app.After = onExit(ctx)
 
+    return app
+}
+
+func main() {
+
+    ctx := context.Background()
+
+    confPath, err := config.Path()
+    if err != nil {
+        fatal("Failed to find config", err)
+    }
+
+    conf, err := config.Load(confPath)
+    if err != nil {
+        fatal("Failed to load config", err)
+    }
+
+    app := newApp(ctx, confPath, conf)
+
     if err := app.Run(os.Args); err != nil {
         if errors.Is(err, errExit) {
             return
         }
 
-        log.WithError(ctx, err).Fatal("Run failed")
+        fatal("Run failed", err)
     }
 
 
@@ -0,0 +1,124 @@
+package main
+
+import (
+    "context"
+    "errors"
+    "io"
+    "net/http"
+    "os"
+    "path/filepath"
+    "strings"
+    "testing"
+
+    "github.com/wthys/advent-of-code-2024/config"
+    "github.com/wthys/advent-of-code-2024/solver"
+)
+
+
+type siteFunc func(req *http.Request) (*http.Response, error)
+
+func (f siteFunc) Do(req *http.Request) (*http.Response, error) {
+    return f(req)
+}
+
+
+// runApp runs the app with `conf` as if it was read from the config file.
+func runApp(t *testing.T, conf string, args ...string) error {
+    t.Helper()
+
+    parsed, err := config.Parse(strings.NewReader(conf))
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    t.Setenv("AOC_SESSION", "")
+    os.Unsetenv("AOC_SESSION")
+
+    app := newApp(context.Background(), "config.toml", parsed)
+    app.Writer = io.Discard
+    return app.Run(append([]string{"aoc2024"}, args...))
+}
+
+
+func TestSubmitSessionFromConfig(t *testing.T) {
+    sessions := []string{}
+    prev := solver.Client
+    solver.Client = siteFunc(func(req *http.Request) (*http.Response, error) {
+        if cookie, err := req.Cookie("session"); err == nil {
+            sessions = append(sessions, cookie.Value)
+        }
+        return &http.Response{
+            StatusCode: http.StatusOK,
+            Header: http.Header{},
+            Body: io.NopCloser(strings.NewReader("<article><p>That's the right answer!</p></article>")),
+        }, nil
+    })
+    t.Cleanup(func() { solver.Client = prev })
+
+    dir := t.TempDir()
+    flags := []string{"--cache-dir", dir, "--answers", filepath.Join(dir, "answers.json"), "--throttle", "0"}
+
+    err := runApp(t, "", append(append([]string{"submit"}, flags...), "1", "1", "42")...)
+    if err == nil || !strings.Contains(err.Error(), "no session token") {
+        t.Fatalf("submit without a session = %v, want an error about the missing session", err)
+    }
+    if len(sessions) != 0 {
+        t.Fatalf("submit without a session sent %v requests, want none", len(sessions))
+    }
+
+    err = runApp(t, `session = "1234567890"`, append(append([]string{"submit"}, flags...), "1", "1", "42")...)
+    if err != nil {
+        t.Fatalf("submit with a session in the config file = %v, want %v", err, nil)
+    }
+    if len(sessions) != 1 || sessions[0] != "1234567890" {
+        t.Fatalf("submit sent sessions %v, want the one of the config file", sessions)
+    }
+}
+
+
+// halfSolver fails its first part, registered for a year without solutions.
+type halfSolver struct{}
+
+
+func init() {
+    solver.Register(halfSolver{})
+}
+
+
+func (s halfSolver) Year() string { return "1999" }
+
+
+func (s halfSolver) Day() string { return "1" }
+
+
+func (s halfSolver) Part1(input []string, opts solver.Options) (string, error) {
+    return solver.Error(errors.New("broken"))
+}
+
+
+func (s halfSolver) Part2(input []string, opts solver.Options) (string, error) {
+    return solver.Solved(len(input))
+}
+
+
+func TestRunRecordsPartialResult(t *testing.T) {
+    dir := t.TempDir()
+    input := filepath.Join(dir, "input.txt")
+    if err := os.WriteFile(input, []byte("a\nb"), 0o644); err != nil {
+        t.Fatal(err)
+    }
+    path := filepath.Join(dir, "answers.json")
+
+    err := runApp(t, "", "run", "--year", "1999", "--input", input, "--record", "--answers", path, "1")
+    if err == nil || !strings.Contains(err.Error(), "broken") {
+        t.Fatalf("run = %v, want the error of part 1", err)
+    }
+
+    answers, err := solver.LoadAnswers(path)
+    if err != nil {
+        t.Fatal(err)
+    }
+    if known := answers["1"]; !known.Part1.IsZero() || known.Part2.String() != "2" {
+        t.Fatalf("recorded answers = %v, want only part 2", answers)
+    }
+}
@@ -0,0 +1,285 @@
+// Package scaffold generates the skeleton of a new day.
+package scaffold
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go/format"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"text/template"
+
+	"github.com/wthys/advent-of-code-2024/solver"
+)
+
+// ModulePath is the import path of the module holding the solutions.
+const ModulePath = "github.com/wthys/advent-of-code-2024"
+
+var (
+	// ErrExists is returned when (part of) the day is already there.
+	ErrExists = errors.New("day already exists")
+	// ErrInvalidDay is returned for days outside of 1 to 25.
+	ErrInvalidDay = errors.New("invalid day")
+)
+
+// Layout tells where the files of a day go. `SrcDir` is the directory of the
+// module, holding the `solutions` package. Days of other years than
+// `solver.DefaultYear` go into subdirectories named after their year.
+type Layout struct {
+	SrcDir      string
+	ExamplesDir string
+	Year        string
+}
+
+func (l Layout) year() string {
+	if l.Year == "" {
+		return solver.DefaultYear
+	}
+	return l.Year
+}
+
+// packagePath returns the path of the package of `day` relative to the
+// `solutions` package.
+func (l Layout) packagePath(day int) string {
+	pkg := fmt.Sprintf("day%d", day)
+	if l.year() == solver.DefaultYear {
+		return pkg
+	}
+	return path.Join(l.year(), pkg)
+}
+
+func (l Layout) packageDir(day int) string {
+	return filepath.Join(l.SrcDir, "solutions", filepath.FromSlash(l.packagePath(day)))
+}
+
+func (l Layout) examplePath(day int) string {
+	return filepath.Join(solver.YearDir(l.ExamplesDir, l.year()), fmt.Sprintf("day%d.txt", day))
+}
+
+func (l Layout) registerPath() string {
+	return filepath.Join(l.SrcDir, "solutions", "register.go")
+}
+
+// New creates the package of `day` with a solution and a test, an empty
+// example and registers the package. Nothing is written when any of these
+// already exist. It returns the paths of the created and changed files.
+func New(l Layout, day string) ([]string, error) {
+	n, err := strconv.Atoi(day)
+	if err != nil || n < 1 || n > 25 {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidDay, day)
+	}
+
+	register, err := os.ReadFile(l.registerPath())
+	if err != nil {
+		return nil, fmt.Errorf("read registrations: %w", err)
+	}
+
+	registered, err := addImport(register, l.year(), n)
+	if err != nil {
+		return nil, err
+	}
+
+	pkgDir := l.packageDir(n)
+	files := map[string]string{
+		filepath.Join(pkgDir, "solution.go"):      solutionTemplate,
+		filepath.Join(pkgDir, "solution_test.go"): testTemplate,
+	}
+
+	for _, path := range []string{pkgDir, l.examplePath(n)} {
+		if _, err := os.Stat(path); err == nil {
+			return nil, fmt.Errorf("%w: %s", ErrExists, path)
+		} else if !errors.Is(err, fs.ErrNotExist) {
+			return nil, err
+		}
+	}
+
+	if err := os.MkdirAll(pkgDir, 0o755); err != nil {
+		return nil, fmt.Errorf("create package: %w", err)
+	}
+
+	written := []string{}
+	for path, text := range files {
+		src, err := render(text, l.year(), n)
+		if err != nil {
+			return written, fmt.Errorf("generate %s: %w", path, err)
+		}
+		if err := os.WriteFile(path, src, 0o644); err != nil {
+			return written, err
+		}
+		written = append(written, path)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(l.examplePath(n)), 0o755); err != nil {
+		return written, fmt.Errorf("create examples: %w", err)
+	}
+	if err := os.WriteFile(l.examplePath(n), nil, 0o644); err != nil {
+		return written, err
+	}
+	written = append(written, l.examplePath(n))
+
+	if err := os.WriteFile(l.registerPath(), registered, 0o644); err != nil {
+		return written, err
+	}
+	written = append(written, l.registerPath())
+
+	return written, nil
+}
+
+func render(text string, year string, day int) ([]byte, error) {
+	tmpl, err := template.New("").Parse(text)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	data := struct {
+		Module string
+		Year   string
+		Day    int
+	}{ModulePath, "", day}
+	if year != solver.DefaultYear {
+		data.Year = year
+	}
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return nil, err
+	}
+
+	return format.Source(buf.Bytes())
+}
+
+var importRe = regexp.MustCompile(`(?m)^(\s*)_ "` + regexp.QuoteMeta(ModulePath) + `/solutions/(?:(\d+)/)?day(\d+)"\n`)
+
+// addImport inserts the blank import of `day` in `year` into the source of
+// `register.go`, keeping the imports ordered by year and day and using the
+// same indentation as the others.
+func addImport(src []byte, year string, day int) ([]byte, error) {
+	matches := importRe.FindAllSubmatchIndex(src, -1)
+
+	indent := "    "
+	insertAt := -1
+	for _, m := range matches {
+		indent = string(src[m[2]:m[3]])
+
+		otherYear := solver.DefaultYear
+		if m[4] >= 0 {
+			otherYear = string(src[m[4]:m[5]])
+		}
+		otherDay, _ := strconv.Atoi(string(src[m[6]:m[7]]))
+
+		if otherYear == year && otherDay == day {
+			return nil, fmt.Errorf("%w: %s day %d is registered", ErrExists, year, day)
+		}
+		if insertAt < 0 && (otherYear > year || otherYear == year && otherDay > day) {
+			insertAt = m[0]
+		}
+	}
+
+	if insertAt < 0 {
+		if len(matches) > 0 {
+			insertAt = matches[len(matches)-1][1]
+		} else {
+			idx := bytes.Index(src, []byte("import ("))
+			if idx < 0 {
+				return nil, errors.New("no import block in register.go")
+			}
+			insertAt = idx + len("import (\n")
+		}
+	}
+
+	pkg := Layout{Year: year}.packagePath(day)
+	line := fmt.Sprintf("%s_ \"%s/solutions/%s\"\n", indent, ModulePath, pkg)
+	return slices.Concat(src[:insertAt], []byte(line), src[insertAt:]), nil
+}
+
+const solutionTemplate = `package day{{.Day}}
+
+import (
+	"fmt"
+
+	"{{.Module}}/solver"
+)
+
+type solution struct{}
+
+func init() {
+	solver.Register(solution{})
+}
+
+func (s solution) Day() string {
+	return "{{.Day}}"
+}
+{{if .Year}}
+func (s solution) Year() string {
+	return "{{.Year}}"
+}
+{{end}}
+func (s solution) Part1(input []string, opts solver.Options) (string, error) {
+	_, err := parseInput(input)
+	if err != nil {
+		return solver.Error(err)
+	}
+
+	return solver.NotImplemented()
+}
+
+func (s solution) Part2(input []string, opts solver.Options) (string, error) {
+	_, err := parseInput(input)
+	if err != nil {
+		return solver.Error(err)
+	}
+
+	return solver.NotImplemented()
+}
+
+func parseInput(input []string) ([]string, error) {
+	lines := []string{}
+	for _, line := range input {
+		if line == "" {
+			continue
+		}
+		lines = append(lines, line)
+	}
+
+	if len(lines) == 0 {
+		return nil, fmt.Errorf("no input found")
+	}
+
+	return lines, nil
+}
+`
+
+const testTemplate = `package day{{.Day}}
+
+import (
+	"testing"
+)
+
+type caseParseInput struct {
+	input []string
+	expected int
+	fails bool
+}
+
+func TestParseInput(t *testing.T) {
+	cases := []caseParseInput{
+		{[]string{"a", "", "b"}, 2, false},
+		{[]string{""}, 0, true},
+	}
+
+	for _, cs := range cases {
+		actual, err := parseInput(cs.input)
+		if (err != nil) != cs.fails {
+			t.Fatalf("parseInput(%q) error = %v, expected failure %v", cs.input, err, cs.fails)
+		}
+		if len(actual) != cs.expected {
+			t.Fatalf("parseInput(%q) expected %v lines, got %v", cs.input, cs.expected, len(actual))
+		}
+	}
+}
+`
@@ -0,0 +1,130 @@
+package scaffold
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const register = `package solutions
+
+import (
+    _ "github.com/wthys/advent-of-code-2024/solutions/day1"
+    _ "github.com/wthys/advent-of-code-2024/solutions/day2"
+    _ "github.com/wthys/advent-of-code-2024/solutions/day10"
+)
+`
+
+func setup(t *testing.T) Layout {
+	root := t.TempDir()
+	l := Layout{SrcDir: filepath.Join(root, "src"), ExamplesDir: filepath.Join(root, "examples")}
+
+	if err := os.MkdirAll(filepath.Join(l.SrcDir, "solutions"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(l.registerPath(), []byte(register), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return l
+}
+
+func TestNew(t *testing.T) {
+	l := setup(t)
+
+	written, err := New(l, "3")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(written) != 4 {
+		t.Fatalf("New() wrote %v, want 4 files", written)
+	}
+
+	src, err := os.ReadFile(filepath.Join(l.SrcDir, "solutions", "day3", "solution.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(src), "package day3\n") || !strings.Contains(string(src), `return "3"`) {
+		t.Fatalf("solution.go = %s, want package day3 for day 3", src)
+	}
+
+	if _, err := os.Stat(filepath.Join(l.SrcDir, "solutions", "day3", "solution_test.go")); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(filepath.Join(l.ExamplesDir, "day3.txt")); err != nil {
+		t.Fatal(err)
+	}
+
+	reg, err := os.ReadFile(l.registerPath())
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "day2\"\n    _ \"github.com/wthys/advent-of-code-2024/solutions/day3\"\n    _ \"github.com/wthys/advent-of-code-2024/solutions/day10\""
+	if !strings.Contains(string(reg), want) {
+		t.Fatalf("register.go = %s, want day3 between day2 and day10", reg)
+	}
+}
+
+func TestNewRefusesExisting(t *testing.T) {
+	l := setup(t)
+
+	if _, err := New(l, "2"); !errors.Is(err, ErrExists) {
+		t.Fatalf("New(2) = %v, want %v", err, ErrExists)
+	}
+
+	os.MkdirAll(l.ExamplesDir, 0o755)
+	os.WriteFile(filepath.Join(l.ExamplesDir, "day4.txt"), []byte("keep me"), 0o644)
+	if _, err := New(l, "4"); !errors.Is(err, ErrExists) {
+		t.Fatalf("New(4) = %v, want %v", err, ErrExists)
+	}
+	if _, err := os.Stat(filepath.Join(l.SrcDir, "solutions", "day4")); err == nil {
+		t.Fatalf("New(4) created the package despite the existing example")
+	}
+
+	for _, day := range []string{"0", "26", "x"} {
+		if _, err := New(l, day); !errors.Is(err, ErrInvalidDay) {
+			t.Fatalf("New(%q) = %v, want %v", day, err, ErrInvalidDay)
+		}
+	}
+}
+
+func TestAddImportLast(t *testing.T) {
+	src, err := addImport([]byte(register), "2024", 11)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasSuffix(string(src), "day10\"\n    _ \"github.com/wthys/advent-of-code-2024/solutions/day11\"\n)\n") {
+		t.Fatalf("addImport(11) = %s, want day11 after day10", src)
+	}
+}
+
+func TestNewOtherYear(t *testing.T) {
+	l := setup(t)
+	l.Year = "2023"
+
+	if _, err := New(l, "2"); err != nil {
+		t.Fatal(err)
+	}
+
+	src, err := os.ReadFile(filepath.Join(l.SrcDir, "solutions", "2023", "day2", "solution.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(src), `return "2023"`) {
+		t.Fatalf("solution.go = %s, want a Year method for 2023", src)
+	}
+
+	if _, err := os.Stat(filepath.Join(l.ExamplesDir, "2023", "day2.txt")); err != nil {
+		t.Fatal(err)
+	}
+
+	reg, err := os.ReadFile(l.registerPath())
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "    _ \"github.com/wthys/advent-of-code-2024/solutions/2023/day2\"\n    _ \"github.com/wthys/advent-of-code-2024/solutions/day1\""
+	if !strings.Contains(string(reg), want) {
+		t.Fatalf("register.go = %s, want 2023/day2 before the days of 2024", reg)
+	}
+}
@@ -19,6 +19,12 @@ func (s solution) Day() string {
 	return "11"
 }
 
+// Sequential keeps the parts from running concurrently, they share the
+// package-level `blinkNMemo`.
+func (s solution) Sequential() bool {
+	return true
+}
+
 func (s solution) Part1(input []string, opts solver.Options) (string, error) {
 	stones, err := parseInput(input)
 	if err != nil {
@@ -2,6 +2,7 @@ package day12
 
 import (
 	"fmt"
+	"io"
 
 	"github.com/wthys/advent-of-code-2024/solver"
 	G "github.com/wthys/advent-of-code-2024/grid"
@@ -68,13 +69,13 @@ func (s solution) Part2(input []string, opts solver.Options) (string, error) {
 
 		cost := A * S
 
-		opts.IfDebugDo(func(_ solver.Options) {
+		opts.IfDebugDo(func(w io.Writer) {
 			b, _ := garden.Bounds()
 			E := edge(region)
 			E.ForEach(func (loc L.Location) {
 				b = b.Accomodate(loc)
 			})
-			garden.PrintBoundsFuncWithLoc(b, func (loc L.Location, v rune, _ error) string {
+			G.Renderer[rune]{Cell: func (loc L.Location, v rune, _ error) string {
 				if region.Has(loc) {
 					return string(v)
 				}
@@ -84,8 +85,8 @@ func (s solution) Part2(input []string, opts solver.Options) (string, error) {
 				}
 
 				return "."
-			})
-			fmt.Printf("== A=%v, S=%v, COST=%v ==\n", A, S, cost)
+			}}.RenderBounds(w, garden, b)
+			opts.Debugf("== A=%v, S=%v, COST=%v ==\n", A, S, cost)
 		})
 		total += cost
 	}
@@ -97,12 +98,8 @@ type Regions []*S.Set[L.Location]
 
 func parseInput(input []string) (*G.Grid[rune], error) {
 	garden := G.WithDefault('.')
-
-	for y, line := range input {
-		for x, plot := range line {
-			loc := L.New(x, y)
-			garden.Set(loc, plot)
-		}
+	if _, err := G.Into(garden, input, G.Runes); err != nil {
+		return nil, err
 	}
 
 	if garden.Len() == 0 {
@@ -1,7 +1,12 @@
 package day14
 
 import (
+	"context"
 	"fmt"
+	"image/color"
+	"io"
+	"os"
+	"strings"
 	"github.com/wthys/advent-of-code-2024/solver"
 	"github.com/wthys/advent-of-code-2024/util"
 	L "github.com/wthys/advent-of-code-2024/location"
@@ -25,19 +30,21 @@ func (s solution) Part1(input []string, opts solver.Options) (string, error) {
 		return solver.Error(err)
 	}
 
-	halfX := MAP_WIDTH/2
-	halfY := MAP_HEIGHT/2
+	area := areaFromOptions(opts)
+
+	halfX := area.Width/2
+	halfY := area.Height/2
 
 	quadrants := []G.Bounds{
-		G.Bounds{0,         halfX - 1, 0,         halfY - 1 },
-		G.Bounds{0,         halfX - 1, halfY + 1, MAP_HEIGHT},
-		G.Bounds{halfX + 1, MAP_WIDTH, 0,         halfY - 1 },
-		G.Bounds{halfX + 1, MAP_WIDTH, halfY + 1, MAP_HEIGHT},
+		G.Bounds{0,         halfX - 1,  0,         halfY - 1  },
+		G.Bounds{0,         halfX - 1,  halfY + 1, area.Height},
+		G.Bounds{halfX + 1, area.Width, 0,         halfY - 1  },
+		G.Bounds{halfX + 1, area.Width, halfY + 1, area.Height},
 	}
 
 	counts := map[int]int{}
 
-	moved := robots.MoveN(100)
+	moved := robots.MoveN(100, area)
 	for _, robot := range moved {
 		for idx, quadrant := range quadrants {
 			if quadrant.Has(robot.Pos) {
@@ -55,12 +62,22 @@ func (s solution) Part1(input []string, opts solver.Options) (string, error) {
 	return solver.Solved(securityFactor)
 }
 
+func (s solution) Part1Context(_ context.Context, input []string, opts solver.Options) (string, error) {
+	return s.Part1(input, opts)
+}
+
 func (s solution) Part2(input []string, opts solver.Options) (string, error) {
+	return s.Part2Context(context.Background(), input, opts)
+}
+
+func (s solution) Part2Context(ctx context.Context, input []string, opts solver.Options) (string, error) {
 	robots, err := parseInput(input)
 	if err != nil {
 		return solver.Error(err)
 	}
 
+	area := areaFromOptions(opts)
+
 	PATTERN_SIZE := 9
 	firstCheck := L.Locations{}
 	for n := range 2 {
@@ -77,18 +94,23 @@ func (s solution) Part2(input []string, opts solver.Options) (string, error) {
 	}
 
 	searchPatterns := []*S.Set[L.Location]{}
-	for x := range MAP_WIDTH - PATTERN_SIZE {
-		for y := range MAP_HEIGHT - PATTERN_SIZE {
+	for x := range area.Width - PATTERN_SIZE {
+		for y := range area.Height - PATTERN_SIZE {
 			root := L.New(x, y)
 			searchPatterns = append(searchPatterns, createSearchPattern(root, firstCheck))
 		}
 	}
 
+	// the robots are back where they started after Width*Height seconds
 	waitTime := 0
 	found := false
-	for !found {
+	for ; waitTime < area.Width * area.Height; waitTime++ {
+		if err := ctx.Err(); err != nil {
+			return solver.Error(err)
+		}
+
 		opts.Debugf("__ checking %v __\n", waitTime)
-		moved := robots.MoveN(waitTime)
+		moved := robots.MoveN(waitTime, area)
 
 		locs := S.New(moved.Positions()...)
 
@@ -106,12 +128,20 @@ func (s solution) Part2(input []string, opts solver.Options) (string, error) {
 		}
 
 		if found {
-			opts.IfDebugDo(func (_ solver.Options) {
-				visualizeRobots(moved)
+			opts.IfDebugDo(func(w io.Writer) {
+				visualizeRobots(w, moved, area)
 			})
+			if path := opts.Param("image", ""); path != "" {
+				if err := saveRobots(path, moved, area); err != nil {
+					return solver.Error(err)
+				}
+			}
 			break
 		}
-		waitTime++
+	}
+
+	if !found {
+		return solver.Error(fmt.Errorf("no christmas tree within %v seconds", waitTime))
 	}
 
 	return solver.Solved(waitTime)
@@ -124,6 +154,10 @@ type (
 		Pos L.Location
 		Dir L.Location
 	}
+	Area struct {
+		Width int
+		Height int
+	}
 )
 
 const (
@@ -131,34 +165,40 @@ const (
 	MAP_HEIGHT = 103
 )
 
-func (r Robot) MoveN(n int) Robot {
+// areaFromOptions reads the "width" and "height" parameters, which default
+// to the size of the real map.
+func areaFromOptions(opts solver.Options) Area {
+	return Area{opts.IntParam("width", MAP_WIDTH), opts.IntParam("height", MAP_HEIGHT)}
+}
+
+func (r Robot) MoveN(n int, area Area) Robot {
 	newPos := r.Pos.Add(r.Dir.Scale(n))
 	for newPos.X < 0 {
-		newPos.X += MAP_WIDTH
+		newPos.X += area.Width
 	}
-	newPos.X = newPos.X % MAP_WIDTH
+	newPos.X = newPos.X % area.Width
 
 	for newPos.Y < 0 {
-		newPos.Y += MAP_HEIGHT
+		newPos.Y += area.Height
 	}
-	newPos.Y = newPos.Y % MAP_HEIGHT
+	newPos.Y = newPos.Y % area.Height
 	return Robot{newPos, r.Dir}
 }
 
-func (r Robot) Move() Robot {
-	return r.MoveN(1)
+func (r Robot) Move(area Area) Robot {
+	return r.MoveN(1, area)
 }
 
-func (robots Robots) MoveN(n int) Robots {
+func (robots Robots) MoveN(n int, area Area) Robots {
 	rs := Robots{}
 	for _, r := range robots {
-		rs = append(rs, r.MoveN(n))
+		rs = append(rs, r.MoveN(n, area))
 	}
 	return rs
 }
 
-func (robots Robots) Move() Robots {
-	return robots.MoveN(1)
+func (robots Robots) Move(area Area) Robots {
+	return robots.MoveN(1, area)
 }
 
 func (robots Robots) Positions() L.Locations {
@@ -169,23 +209,46 @@ func (robots Robots) Positions() L.Locations {
 	return locs
 }
 
-func visualizeRobots(robots Robots) {
-	robotLocs := map[L.Location]int{}
-	for _, r  := range robots {
-		robotLocs[r.Pos] += 1
+func visualizeRobots(w io.Writer, robots Robots, area Area) {
+	g := G.NewDense[int](G.Bounds{0, area.Width - 1, 0, area.Height - 1})
+
+	G.Renderer[int]{
+		Cell: func(_ L.Location, _ int, _ error) string {
+			return "⬛"
+		},
+		Overlays: []G.Overlay{G.PathOverlay("robot", "🟩", G.NoColor, robots.Positions())},
+	}.Render(w, g)
+}
+
+// saveRobots draws the robots as a PNG or, for paths ending in .svg, an SVG
+// image.
+func saveRobots(path string, robots Robots, area Area) error {
+	g := G.NewDense[int](G.Bounds{0, area.Width - 1, 0, area.Height - 1})
+	for _, pos := range robots.Positions() {
+		g.Set(pos, 1)
 	}
-	g := G.WithDefault(0)
-	for loc, n := range robotLocs {
-		g.Set(loc, n)
+
+	painter := G.Painter[int]{
+		Palette: G.ColorMap(map[int]color.Color{1: color.RGBA{0, 160, 0, 255}}, color.Black),
+		Scale: 4,
 	}
 
-	b := G.Bounds{0, MAP_WIDTH, 0, MAP_HEIGHT}
-	g.PrintBoundsFuncWithLoc(b, func(_ L.Location, v int, _ error) string {
-		if v == 0 {
-			return "⬛"
-		}
-		return "🟩"
-	})
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	if strings.HasSuffix(path, ".svg") {
+		err = painter.WriteSVG(f, g)
+	} else {
+		err = painter.WritePNG(f, g)
+	}
+	if err != nil {
+		return err
+	}
+
+	return f.Close()
 }
 
 func parseInput(input []string) (Robots, error) {
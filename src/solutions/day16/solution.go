@@ -2,6 +2,7 @@ package day16
 
 import (
 	"fmt"
+	"io"
 	"github.com/wthys/advent-of-code-2024/solver"
 	PF "github.com/wthys/advent-of-code-2024/pathfinding"
 	L "github.com/wthys/advent-of-code-2024/location"
@@ -41,9 +42,9 @@ func (s solution) Part1(input []string, opts solver.Options) (string, error) {
 		if length < minLength {
 			minLength = length
 		}
-		// opts.IfDebugDo(func (_ solver.Options) {
+		// opts.IfDebugDo(func(w io.Writer) {
 		// 	opts.Debugf("=== %v points ===\n", length)
-		// 	visualisePath(step0, stepN, Steps(path), walkable)
+		// 	visualisePath(w, step0, stepN, Steps(path), walkable)
 		// })
 	}
 
@@ -89,8 +90,8 @@ func (s solution) Part2(input []string, opts solver.Options) (string, error) {
 		})
 	}
 
-	opts.IfDebugDo(func(_ solver.Options) {
-		visualiseSpots(spots, walkable)
+	opts.IfDebugDo(func(w io.Writer) {
+		visualiseSpots(w, spots, walkable)
 	})
 
 	return solver.Solved(spots.Len())
@@ -181,81 +182,74 @@ func (steps Steps) Cost() int {
 }
 
 func parseInput(input []string) (L.Location, L.Location, *S.Set[L.Location], error) {
-	start := L.New(0,0)
-	end := start
-	walkable := S.NewFor(start)
-
 	lnil := L.New(-1,-1)
 
-	for y, line := range input {
-		for x, space := range line {
-			pos := L.New(x, y)
-			switch space {
-			case '.':
-				walkable.Add(pos)
-			case 'S':
-				start = pos
-			case 'E':
-				end = pos
-			}
-		}
+	_, catalog, err := G.FromLinesWithCatalog(input, G.Runes, G.Mark("SE."))
+	if err != nil {
+		return lnil, lnil, nil, err
 	}
 
-	if start.Manhattan() == 0 {
-		return lnil, lnil, nil, fmt.Errorf("no start found")
+	start, err := catalog.Single('S')
+	if err != nil {
+		return lnil, lnil, nil, fmt.Errorf("no start found: %w", err)
 	}
 
-	if end.Manhattan() == 0 {
-		return lnil, lnil, nil, fmt.Errorf("no end found")
+	end, err := catalog.Single('E')
+	if err != nil {
+		return lnil, lnil, nil, fmt.Errorf("no end found: %w", err)
 	}
 
-	if walkable.Len() == 0 {
+	if len(catalog['.']) == 0 {
 		return lnil, lnil, nil, fmt.Errorf("no walkable spaces found")
 	}
 
+	walkable := S.New(catalog['.']...)
 	walkable.Add(start).Add(end)
 
 	return start, end, walkable, nil
 }
 
-func visualisePath(start Step, end Step, steps Steps, walkable *S.Set[L.Location]) {
-	g := G.WithDefault("#")
-	walkable.ForEach(func (loc L.Location) {
-		g.Set(loc, ".")
-	})
-
+func visualisePath(w io.Writer, start Step, end Step, steps Steps, walkable *S.Set[L.Location]) {
+	arrows := map[L.Location]string{}
 	for _, step := range steps {
-		g.Set(step.Pos, DIRMAP[step.Dir])
+		arrows[step.Pos] = ARROWS[DIRMAP[step.Dir]]
 	}
 
-	g.Set(start.Pos, "S")
-	g.Set(end.Pos, "E")
-
-	mapper := map[string]string {
-		"#": "■",
-		".": " ",
-		">": "→",
-		"<": "←",
-		"^": "↑",
-		"v": "↓",
-		"S": "S",
-		"E": "E",
-	}
+	G.Renderer[string]{
+		Cell: func(loc L.Location, v string, _ error) string {
+			if arrow, ok := arrows[loc]; ok {
+				return arrow
+			}
+			return v
+		},
+		Overlays: []G.Overlay{
+			G.LocationOverlay("start", "S", G.Green, start.Pos),
+			G.LocationOverlay("end", "E", G.Red, end.Pos),
+		},
+		Color: true,
+	}.Render(w, maze(walkable))
+}
 
-	g.PrintFunc(func(v string, _ error) string {
-		return mapper[v]
-	})
+func visualiseSpots(w io.Writer, spots *S.Set[L.Location], walkable *S.Set[L.Location]) {
+	G.Renderer[string]{
+		Overlays: []G.Overlay{G.SetOverlay("best seat", "O", G.Yellow, spots)},
+		Color: true,
+		Legend: true,
+	}.Render(w, maze(walkable))
 }
 
-func visualiseSpots(spots *S.Set[L.Location], walkable *S.Set[L.Location]) {
-	g := G.WithDefault("#")
-	walkable.ForEach(func (loc L.Location) {
-		g.Set(loc, ".")
-	})
+var ARROWS = map[string]string{
+	">": "→",
+	"<": "←",
+	"^": "↑",
+	"v": "↓",
+}
 
-	spots.ForEach(func (loc L.Location) {
-		g.Set(loc, "O")
+// maze returns a grid with " " for the walkable spaces and "■" for walls.
+func maze(walkable *S.Set[L.Location]) *G.Grid[string] {
+	g := G.WithDefault("■")
+	walkable.ForEach(func (loc L.Location) {
+		g.Set(loc, " ")
 	})
-
-	g.Print()
-}
\ No newline at end of file
+	return g
+}
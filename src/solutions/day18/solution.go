@@ -1,6 +1,7 @@
 package day18
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/wthys/advent-of-code-2024/solver"
@@ -21,29 +22,25 @@ func (s solution) Day() string {
 }
 
 func (s solution) Part1(input []string, opts solver.Options) (string, error) {
+	return solver.Text(s.Part1Typed(context.Background(), input, opts))
+}
+
+func (s solution) Part2(input []string, opts solver.Options) (string, error) {
+	return solver.Text(s.Part2Typed(context.Background(), input, opts))
+}
+
+func (s solution) Part1Typed(_ context.Context, input []string, opts solver.Options) (solver.Answer, error) {
 	locations, err := parseInput(input)
 	if err != nil {
-		return solver.Error(err)
+		return solver.ErrorAnswer(err)
 	}
 
-	max := 0
-	for _, loc := range locations {
-		if max < loc.X {
-			max = loc.X
-		}
-		if max < loc.Y {
-			max = loc.Y
-		}
-	}
+	max := opts.IntParam("size", MEMORY_SIZE)
+	upper := min(opts.IntParam("bytes", FALLEN_BYTES), len(locations))
 
 	start := L.New(0, 0)
 	end := L.New(max, max)
 
-	upper := 12
-	if len(locations) > 1024 {
-		upper = 1024
-	}
-
 	excluded := S.New(locations[:upper]...)
 
 	neejberFn := func(loc L.Location) []L.Location {
@@ -64,37 +61,29 @@ func (s solution) Part1(input []string, opts solver.Options) (string, error) {
 
 	shortest := pf.ShortestPathLengthTo(end)
 
-	return solver.Solved(shortest)
+	return solver.SolvedAnswer(shortest)
 }
 
-func (s solution) Part2(input []string, opts solver.Options) (string, error) {
+func (s solution) Part2Typed(ctx context.Context, input []string, opts solver.Options) (solver.Answer, error) {
 	locations, err := parseInput(input)
 	if err != nil {
-		return solver.Error(err)
+		return solver.ErrorAnswer(err)
 	}
 
-	max := 0
-	for _, loc := range locations {
-		if max < loc.X {
-			max = loc.X
-		}
-		if max < loc.Y {
-			max = loc.Y
-		}
-	}
+	max := opts.IntParam("size", MEMORY_SIZE)
+	upper := min(opts.IntParam("bytes", FALLEN_BYTES), len(locations))
 
 	start := L.New(0, 0)
 	end := L.New(max, max)
 
-	upper := 12
-	if len(locations) > 1024 {
-		upper = 1024
-	}
-
 	lo := upper
 	hi := len(locations)-1
 
 	for lo+1 < hi {
+		if err := ctx.Err(); err != nil {
+			return solver.ErrorAnswer(err)
+		}
+
 		mid := (lo + hi) / 2
 		excluded := S.New(locations[:mid]...)
 		neejberFn := func(loc L.Location) []L.Location {
@@ -140,13 +129,20 @@ func (s solution) Part2(input []string, opts solver.Options) (string, error) {
 		pf := PF.ConstructDijkstra(start, neejberFn)
 		shortest := pf.ShortestPathLengthTo(end)
 		if shortest == PF.INFINITE {
-			return solver.Solved(locations[limit-1])
+			return solver.SolvedAnswer(locations[limit-1])
 		}
 	}
 
-	return solver.NotImplemented()
+	return solver.NotImplementedAnswer()
 }
 
+const (
+	// MEMORY_SIZE is the largest coordinate of the memory space.
+	MEMORY_SIZE = 70
+	// FALLEN_BYTES is the number of bytes that have fallen in part 1.
+	FALLEN_BYTES = 1024
+)
+
 func parseInput(input []string) (L.Locations, error) {
 	locations := L.Locations{}
 	for lineno, line := range input {
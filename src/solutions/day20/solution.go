@@ -12,6 +12,9 @@ import (
 
 type solution struct{}
 
+// MIN_SAVING is the number of picoseconds a cheat has to save to be counted.
+const MIN_SAVING = 100
+
 func init() {
 	solver.Register(solution{})
 }
@@ -26,6 +29,8 @@ func (s solution) Part1(input []string, opts solver.Options) (string, error) {
 		return solver.Error(err)
 	}
 
+	saving := opts.IntParam("saving", MIN_SAVING)
+
 	step0 := Step{start}
 	stepN := Step{end}
 	neejberFn := func(step Step) []Step {
@@ -36,12 +41,12 @@ func (s solution) Part1(input []string, opts solver.Options) (string, error) {
 
 	baselinePath := append([]Step{step0}, pf.ShortestPathTo(stepN)...)
 	baseline := pathLength(baselinePath)
-	opts.Debugf("__ baseline = %v ps\n", baseline)
+	opts.Debug("baseline", "ps", baseline)
 
 	pathLengths := map[int]int{}
 
 	for idx, step := range baselinePath[:baseline] {
-		opts.Debugf("__ checking step %v : %v (%v%%)\n", idx, step, 100 * idx / (baseline + 1))
+		opts.Debug("checking step", "idx", idx, "step", step, "progress", 100 * idx / (baseline + 1))
 		for cidx := baseline; cidx > idx; cidx-- {
 			cheat := baselinePath[cidx]
 			dist := cheat.Pos.Subtract(step.Pos).Manhattan()
@@ -54,8 +59,8 @@ func (s solution) Part1(input []string, opts solver.Options) (string, error) {
 
 	count := 0
 	for length, n := range pathLengths {
-		if baseline - length >= 100 {
-			opts.Debugf("____ %v cheats save %v ps (%v ps)\n", n, baseline - length, length)
+		if baseline - length >= saving {
+			opts.Debug("cheats", "count", n, "saves", baseline - length, "length", length)
 			count += n
 		}
 	}
@@ -69,6 +74,8 @@ func (s solution) Part2(input []string, opts solver.Options) (string, error) {
 		return solver.Error(err)
 	}
 
+	saving := opts.IntParam("saving", MIN_SAVING)
+
 	step0 := Step{start}
 	stepN := Step{end}
 	neejberFn := func(step Step) []Step {
@@ -79,12 +86,12 @@ func (s solution) Part2(input []string, opts solver.Options) (string, error) {
 
 	baselinePath := append([]Step{step0}, pf.ShortestPathTo(stepN)...)
 	baseline := pathLength(baselinePath)
-	opts.Debugf("__ baseline = %v ps\n", baseline)
+	opts.Debug("baseline", "ps", baseline)
 
 	pathLengths := map[int]int{}
 
 	for idx, step := range baselinePath[:baseline] {
-		opts.Debugf("__ checking step %v : %v (%v%%)\n", idx, step, 100 * idx / (baseline + 1))
+		opts.Debug("checking step", "idx", idx, "step", step, "progress", 100 * idx / (baseline + 1))
 		for cidx := baseline; cidx > idx; cidx-- {
 			cheat := baselinePath[cidx]
 			dist := cheat.Pos.Subtract(step.Pos).Manhattan()
@@ -97,8 +104,8 @@ func (s solution) Part2(input []string, opts solver.Options) (string, error) {
 
 	count := 0
 	for length, n := range pathLengths {
-		if baseline - length >= 100 {
-			opts.Debugf("____ %v cheats save %v ps (%v ps)\n", n, baseline - length, length)
+		if baseline - length >= saving {
+			opts.Debug("cheats", "count", n, "saves", baseline - length, "length", length)
 			count += n
 		}
 	}
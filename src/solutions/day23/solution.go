@@ -1,7 +1,9 @@
 package day23
 
 import (
+	"context"
 	"fmt"
+	"io"
 	"strings"
 	"slices"
 
@@ -41,7 +43,7 @@ func (s solution) Part1(input []string, opts solver.Options) (string, error) {
 		})
 	}
 
-	opts.IfDebugDo(func(_ solver.Options) {
+	opts.IfDebugDo(func(_ io.Writer) {
 		trios.ForEach(func (s string) {
 			opts.Debugf("__ %v\n", s)
 		})
@@ -50,7 +52,15 @@ func (s solution) Part1(input []string, opts solver.Options) (string, error) {
 	return solver.Solved(trios.Len())
 }
 
+func (s solution) Part1Context(_ context.Context, input []string, opts solver.Options) (string, error) {
+	return s.Part1(input, opts)
+}
+
 func (s solution) Part2(input []string, opts solver.Options) (string, error) {
+	return s.Part2Context(context.Background(), input, opts)
+}
+
+func (s solution) Part2Context(ctx context.Context, input []string, opts solver.Options) (string, error) {
 	links, err := parseInput(input)
 	if err != nil {
 		return solver.Error(err)
@@ -70,6 +80,10 @@ func (s solution) Part2(input []string, opts solver.Options) (string, error) {
 
 		// for n := util.Max(len(largest)/3+2, 2); n <= len(connected); n++ {
 		for n := len(connected); n >= util.Max(len(largest)/3+2, 2); n-- {
+			if err := ctx.Err(); err != nil {
+				return solver.Error(err)
+			}
+
 			// opts.Debugf("__ checking %v / %v\n", connected, n)
 			found := false
 			
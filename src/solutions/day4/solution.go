@@ -70,30 +70,24 @@ func (s solution) Part2(input []string, opts solver.Options) (string, error) {
 	return solver.Solved(count)
 }
 
-func parseInput(input []string) (*G.Grid[rune], error) {
-	g := G.WithDefault(rune('.'))
-
-	for y, line := range input {
-		for x, letter := range line {
-			if !(letter == 'X' || letter == 'M' || letter == 'A' || letter == 'S') {
-				return nil, fmt.Errorf("letter '%v' on line %v is not valid", letter, y+1)
-			}
-			g.Set(L.New(x, y), letter)
+func parseInput(input []string) (*G.Dense[rune], error) {
+	return G.DenseFromLines(input, func (letter rune) (rune, error) {
+		if !(letter == 'X' || letter == 'M' || letter == 'A' || letter == 'S') {
+			return letter, fmt.Errorf("letter '%v' is not valid", letter)
 		}
-	}
-
-	return g, nil
+		return letter, nil
+	})
 }
 
-func matchXmas(grid *G.Grid[rune], start, direction L.Location) bool {
+func matchXmas(grid G.Interface[rune], start, direction L.Location) bool {
 	return matchWord(grid, "XMAS", start, direction)
 }
 
-func matchMas(grid *G.Grid[rune], start, direction L.Location) bool {
+func matchMas(grid G.Interface[rune], start, direction L.Location) bool {
 	return matchWord(grid, "MAS", start, direction)
 }
 
-func matchWord(grid *G.Grid[rune], word string, start, direction L.Location) bool {
+func matchWord(grid G.Interface[rune], word string, start, direction L.Location) bool {
 	for n, letter := range word {
 		l, ok := grid.Get(start.Add(direction.Scale(n)))
 		if ok != nil || l != letter {
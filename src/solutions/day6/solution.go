@@ -2,6 +2,7 @@ package day6
 
 import (
 	"fmt"
+	"io"
 	"github.com/wthys/advent-of-code-2024/solver"
 	G "github.com/wthys/advent-of-code-2024/grid"
 	L "github.com/wthys/advent-of-code-2024/location"
@@ -160,13 +161,11 @@ type Region struct {
 }
 type RegionMap []Region
 
-func visualiseRegions(grid *G.Grid[string], regionMap RegionMap) {
-	grid.PrintFuncWithLoc(func (loc L.Location, v string, _ error) string {
-		for _, region := range regionMap {
-			if region.locations.Has(loc) {
-				return region.display
-			}
-		}
-		return v
-	})
-}
\ No newline at end of file
+func visualiseRegions(w io.Writer, grid *G.Grid[string], regionMap RegionMap) {
+	overlays := []G.Overlay{}
+	for _, region := range regionMap {
+		overlays = append(overlays, G.SetOverlay("", region.display, G.NoColor, region.locations))
+	}
+
+	G.Renderer[string]{Overlays: overlays}.Render(w, grid)
+}
@@ -2,6 +2,7 @@ package day8
 
 import (
 	"fmt"
+	"io"
 	"github.com/wthys/advent-of-code-2024/solver"
 	"github.com/wthys/advent-of-code-2024/util"
 	G "github.com/wthys/advent-of-code-2024/grid"
@@ -30,7 +31,7 @@ func (s solution) Part1(input []string, opts solver.Options) (string, error) {
 
 	antinodes := S.New[L.Location]()
 	for antenna, list := range catalog {
-		opts.Debugf("__ %v -> %v\n", antenna, list)
+		opts.Debugf("__ %c -> %v\n", antenna, list)
 		util.CombinationDo(list, 2, func (locs []L.Location) {
 			a := locs[0]
 			b := locs[1]
@@ -55,14 +56,14 @@ func (s solution) Part1(input []string, opts solver.Options) (string, error) {
 		})
 	}
 
-	opts.IfDebugDo(func (_ solver.Options) {
-		grid.PrintFuncWithLoc(func (loc L.Location, v string, _ error) string {
+	opts.IfDebugDo(func(w io.Writer) {
+		G.Renderer[string]{Cell: func (loc L.Location, v string, _ error) string {
 			if antinodes.Has(loc) {
 				return "#"
 			}
 
 			return v
-		})
+		}}.Render(w, grid)
 	})
 
 	return solver.Solved(antinodes.Len())
@@ -78,7 +79,7 @@ func (s solution) Part2(input []string, opts solver.Options) (string, error) {
 
 	antinodes := S.New[L.Location]()
 	for antenna, list := range catalog {
-		opts.Debugf("__ %v -> %v\n", antenna, list)
+		opts.Debugf("__ %c -> %v\n", antenna, list)
 		util.CombinationDo(list, 2, func (locs []L.Location) {
 			a := locs[0]
 			b := locs[1]
@@ -115,49 +116,28 @@ func (s solution) Part2(input []string, opts solver.Options) (string, error) {
 		})
 	}
 
-	opts.IfDebugDo(func (_ solver.Options) {
-		grid.PrintFuncWithLoc(func (loc L.Location, v string, _ error) string {
+	opts.IfDebugDo(func(w io.Writer) {
+		G.Renderer[string]{Cell: func (loc L.Location, v string, _ error) string {
 			if antinodes.Has(loc) {
 				return "#"
 			}
 
 			return v
-		})
+		}}.Render(w, grid)
 	})
 
 	return solver.Solved(antinodes.Len())
 }
 
-type AntennaCatalog map[string]L.Locations
-
-func parseInput(input []string) (*G.Grid[string], AntennaCatalog, error) {
-	grid := G.New[string]()
-	tempCatalog := map[rune]*S.Set[L.Location]{}
-
-	for y, line := range input {
-		for x, v := range line {
-			loc := L.New(x, y)
-
-			grid.Set(loc, string(v))
-			if v != '.' {
-				lst, ok := tempCatalog[v]
-				if !ok {
-					lst = S.New(loc)
-					tempCatalog[v] = lst
-				}
-				lst.Add(loc)
-			}
-		}
+func parseInput(input []string) (*G.Grid[string], G.Catalog, error) {
+	grid, catalog, err := G.FromLinesWithCatalog(input, G.Strings, G.Background("."))
+	if err != nil {
+		return nil, nil, err
 	}
 
 	if grid.Len() == 0 {
 		return nil, nil, fmt.Errorf("no map to be found")
 	}
 
-	catalog := AntennaCatalog{}
-	for v, locs := range tempCatalog {
-		catalog[string(v)] = L.Locations(locs.Values())
-	}
-
 	return grid, catalog, nil
 }
@@ -0,0 +1,160 @@
+package solver
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+)
+
+// Kind describes the type of the value behind an `Answer`.
+type Kind string
+
+const (
+	// KindNone is used for answers of which the type is not known, like
+	// answers given as plain text.
+	KindNone   Kind = ""
+	KindInt    Kind = "int"
+	KindUint   Kind = "uint"
+	KindFloat  Kind = "float"
+	KindBool   Kind = "bool"
+	KindString Kind = "string"
+)
+
+// Answer holds the value that solved a puzzle part, its kind and its text. The
+// text is the same as what `Solved` produces.
+type Answer struct {
+	value any
+	kind  Kind
+	text  string
+}
+
+// NoAnswer is the answer of a part that was not solved.
+var NoAnswer = Answer{nil, KindNone, Unsolved}
+
+// NewAnswer wraps `value` into an `Answer`. Types other than the basic kinds
+// are identified by their type name, e.g. "location.Location".
+func NewAnswer[T any](value T) Answer {
+	return Answer{value, kindOf(value), fmt.Sprintf("%v", value)}
+}
+
+// TextAnswer creates an `Answer` from the text produced by a `Solver`. Only a
+// `TypedSolver` knows the kind of its answers, so the text is kept as it is,
+// without a kind.
+func TextAnswer(text string) Answer {
+	if text == Unsolved {
+		return NoAnswer
+	}
+	return UntypedAnswer(text)
+}
+
+// UntypedAnswer creates an `Answer` of `KindNone`, for answers of which only
+// the text is known.
+func UntypedAnswer(text string) Answer {
+	return Answer{nil, KindNone, text}
+}
+
+func kindOf(value any) Kind {
+	v := reflect.ValueOf(value)
+	if !v.IsValid() {
+		return KindNone
+	}
+
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return KindInt
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return KindUint
+	case reflect.Float32, reflect.Float64:
+		return KindFloat
+	case reflect.Bool:
+		return KindBool
+	case reflect.String:
+		return KindString
+	default:
+		return Kind(v.Type().String())
+	}
+}
+
+// SolvedAnswer is the typed counterpart of `Solved`.
+func SolvedAnswer[T any](value T) (Answer, error) {
+	return NewAnswer(value), nil
+}
+
+// NotImplementedAnswer is the typed counterpart of `NotImplemented`.
+func NotImplementedAnswer() (Answer, error) {
+	return NoAnswer, ErrNotImplemented
+}
+
+// ErrorAnswer is the typed counterpart of `Error`.
+func ErrorAnswer(err error) (Answer, error) {
+	return NoAnswer, err
+}
+
+// Text adapts the result of a typed part to the `Solver` interface.
+func Text(answer Answer, err error) (string, error) {
+	return answer.String(), err
+}
+
+// Value returns the original value, if known.
+func (a Answer) Value() any {
+	return a.value
+}
+
+func (a Answer) Kind() Kind {
+	return a.kind
+}
+
+func (a Answer) String() string {
+	if a.IsZero() {
+		return Unsolved
+	}
+	return a.text
+}
+
+func (a Answer) IsZero() bool {
+	return a.value == nil && a.kind == KindNone && a.text == ""
+}
+
+// Equal compares both the text and the kind of two answers. The kind is
+// ignored when either answer has none.
+func (a Answer) Equal(other Answer) bool {
+	if a.String() != other.String() {
+		return false
+	}
+	return a.kind == KindNone || other.kind == KindNone || a.kind == other.kind
+}
+
+type answerJSON struct {
+	Kind Kind   `json:"kind"`
+	Text string `json:"text"`
+}
+
+// MarshalJSON writes answers without a kind as plain strings, others as an
+// object with their kind and text.
+func (a Answer) MarshalJSON() ([]byte, error) {
+	if a.kind == KindNone {
+		return json.Marshal(a.String())
+	}
+	return json.Marshal(answerJSON{a.kind, a.text})
+}
+
+// UnmarshalJSON reads answers written by `MarshalJSON`. The original value is
+// not restored.
+func (a *Answer) UnmarshalJSON(data []byte) error {
+	if bytes.HasPrefix(bytes.TrimSpace(data), []byte(`"`)) {
+		var text string
+		if err := json.Unmarshal(data, &text); err != nil {
+			return err
+		}
+		*a = UntypedAnswer(text)
+		return nil
+	}
+
+	var aj answerJSON
+	if err := json.Unmarshal(data, &aj); err != nil {
+		return fmt.Errorf("parse answer: %w", err)
+	}
+	*a = Answer{nil, aj.Kind, aj.Text}
+	return nil
+}
@@ -0,0 +1,54 @@
+package solver
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+type caseAnswer struct {
+	answer Answer
+	kind   Kind
+	text   string
+}
+
+func TestAnswerKinds(t *testing.T) {
+	cases := []caseAnswer{
+		{NewAnswer(42), KindInt, "42"},
+		{NewAnswer(int64(1) << 60), KindInt, "1152921504606846976"},
+		{NewAnswer(uint8(7)), KindUint, "7"},
+		{NewAnswer(1.5), KindFloat, "1.5"},
+		{NewAnswer("co,de,ka,ta"), KindString, "co,de,ka,ta"},
+		{NewAnswer(point{6, 1}), Kind("solver.point"), "(6,1)"},
+		{TextAnswer("31"), KindNone, "31"},
+		{TextAnswer("0123"), KindNone, "0123"},
+		{TextAnswer("5,7,3,0"), KindNone, "5,7,3,0"},
+		{TextAnswer(Unsolved), KindNone, Unsolved},
+		{Answer{}, KindNone, Unsolved},
+	}
+
+	for _, cs := range cases {
+		if cs.answer.Kind() != cs.kind || cs.answer.String() != cs.text {
+			t.Fatalf("answer %#v = %q of kind %q, want %q of kind %q", cs.answer, cs.answer, cs.answer.Kind(), cs.text, cs.kind)
+		}
+	}
+}
+
+func TestAnswerJSON(t *testing.T) {
+	answers := []Answer{NewAnswer(42), NewAnswer(point{6, 1}), {nil, KindNone, "legacy"}}
+
+	for _, answer := range answers {
+		data, err := json.Marshal(answer)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var decoded Answer
+		if err := json.Unmarshal(data, &decoded); err != nil {
+			t.Fatal(err)
+		}
+
+		if !decoded.Equal(answer) || decoded.Kind() != answer.Kind() {
+			t.Fatalf("json round trip of %v via %s = %v of kind %q", answer, data, decoded, decoded.Kind())
+		}
+	}
+}
@@ -0,0 +1,123 @@
+package solver
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+)
+
+var (
+	// ErrWrongAnswer returns when a result does not match its known answer.
+	ErrWrongAnswer = errors.New("wrong answer")
+)
+
+type (
+	// KnownAnswer holds the verified answers for a single day. Empty parts are
+	// not verified.
+	KnownAnswer struct {
+		Part1 Answer `json:"part1,omitzero"`
+		Part2 Answer `json:"part2,omitzero"`
+	}
+
+	// KnownAnswers maps days to their verified answers.
+	KnownAnswers map[string]KnownAnswer
+
+	// Mismatch describes a part whose result differs from the known answer.
+	Mismatch struct {
+		Day  string
+		Part int
+		Want Answer
+		Got  Answer
+	}
+)
+
+// LoadAnswers reads the known answers from a JSON file at `path`. A missing
+// file results in an empty set of answers.
+func LoadAnswers(path string) (KnownAnswers, error) {
+	answers := KnownAnswers{}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return answers, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read answers: %w", err)
+	}
+
+	if err := json.Unmarshal(data, &answers); err != nil {
+		return nil, fmt.Errorf("parse answers %s: %w", path, err)
+	}
+
+	return answers, nil
+}
+
+// SaveAnswers writes the known answers to a JSON file at `path`.
+func SaveAnswers(path string, answers KnownAnswers) error {
+	data, err := json.MarshalIndent(answers, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode answers: %w", err)
+	}
+
+	if err := os.WriteFile(path, append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("write answers: %w", err)
+	}
+	return nil
+}
+
+// Record stores the solved parts of `r` as known answers, leaving the answers
+// for unsolved parts untouched.
+func (a KnownAnswers) Record(r Result) {
+	known := a[r.Name]
+	if part1 := r.TypedAnswer(1); part1.String() != Unsolved {
+		known.Part1 = part1
+	}
+	if part2 := r.TypedAnswer(2); part2.String() != Unsolved {
+		known.Part2 = part2
+	}
+	if !known.Part1.IsZero() || !known.Part2.IsZero() {
+		a[r.Name] = known
+	}
+}
+
+// Verify compares the parts of `r` against the known answers and returns the
+// parts that differ.
+func (a KnownAnswers) Verify(r Result) []Mismatch {
+	known, ok := a[r.Name]
+	if !ok {
+		return nil
+	}
+
+	mismatches := []Mismatch{}
+	for part, want := range []Answer{known.Part1, known.Part2} {
+		got := r.TypedAnswer(part + 1)
+		if !want.IsZero() && !want.Equal(got) {
+			mismatches = append(mismatches, Mismatch{r.Name, part + 1, want, got})
+		}
+	}
+	return mismatches
+}
+
+// VerifyAll verifies all `results` and returns an error wrapping
+// `ErrWrongAnswer` that lists every mismatch, if any.
+func (a KnownAnswers) VerifyAll(results []Result) error {
+	errs := []error{}
+	for _, r := range results {
+		for _, m := range a.Verify(r) {
+			errs = append(errs, m)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (m Mismatch) Error() string {
+	if m.Got.String() == m.Want.String() {
+		return fmt.Sprintf("day %s part %d: %v: got %q of kind %q, want kind %q", m.Day, m.Part, ErrWrongAnswer, m.Got, m.Got.Kind(), m.Want.Kind())
+	}
+	return fmt.Sprintf("day %s part %d: %v: got %q, want %q", m.Day, m.Part, ErrWrongAnswer, m.Got, m.Want)
+}
+
+func (m Mismatch) Unwrap() error {
+	return ErrWrongAnswer
+}
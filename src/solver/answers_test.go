@@ -0,0 +1,115 @@
+package solver
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestAnswersRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "answers.json")
+
+	answers, err := LoadAnswers(path)
+	if err != nil || len(answers) != 0 {
+		t.Fatalf("LoadAnswers(missing) = %v, %v, want empty, %v", answers, err, nil)
+	}
+
+	answers.Record(Result{Name: "1", Part1: "11", Part2: "31", Answers: []Answer{NewAnswer(11), NewAnswer(31)}})
+	answers.Record(Result{Name: "2", Part1: "2", Part2: Unsolved})
+	answers.Record(Result{Name: "3", Part1: Unsolved, Part2: Unsolved})
+
+	if err := SaveAnswers(path, answers); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded, err := LoadAnswers(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := KnownAnswers{
+		"1": {NewAnswer(11), NewAnswer(31)},
+		"2": {UntypedAnswer("2"), Answer{}},
+	}
+	if len(loaded) != len(want) {
+		t.Fatalf("LoadAnswers() = %v, want %v", loaded, want)
+	}
+	for day, known := range want {
+		got := loaded[day]
+		if !got.Part1.Equal(known.Part1) || got.Part1.Kind() != known.Part1.Kind() ||
+			!got.Part2.Equal(known.Part2) || got.Part2.Kind() != known.Part2.Kind() {
+			t.Fatalf("LoadAnswers()[%v] = %v, want %v", day, got, known)
+		}
+	}
+}
+
+func TestAnswersVerify(t *testing.T) {
+	answers := KnownAnswers{
+		"1": {NewAnswer(11), NewAnswer(31)},
+		"2": {NewAnswer(2), Answer{}},
+	}
+
+	results := []Result{
+		{Name: "1", Part1: "11", Part2: "30"},
+		{Name: "2", Part1: "2", Part2: "4"},
+		{Name: "3", Part1: "161", Part2: "48"},
+	}
+
+	mismatches := answers.Verify(results[0])
+	want := []Mismatch{{"1", 2, NewAnswer(31), UntypedAnswer("30")}}
+	if !reflect.DeepEqual(mismatches, want) {
+		t.Fatalf("Verify(%v) = %v, want %v", results[0], mismatches, want)
+	}
+
+	for _, r := range results[1:] {
+		if mismatches := answers.Verify(r); len(mismatches) != 0 {
+			t.Fatalf("Verify(%v) = %v, want none", r, mismatches)
+		}
+	}
+
+	if err := answers.VerifyAll(results); !errors.Is(err, ErrWrongAnswer) {
+		t.Fatalf("VerifyAll() = %v, want %v", err, ErrWrongAnswer)
+	}
+	if err := answers.VerifyAll(results[1:]); err != nil {
+		t.Fatalf("VerifyAll() = %v, want %v", err, nil)
+	}
+
+	answers["4"] = KnownAnswer{NewAnswer([]int{1, 2}), Answer{}}
+	sliced := Result{Name: "4", Part1: "[1 3]", Part2: Unsolved, Answers: []Answer{NewAnswer([]int{1, 3}), NoAnswer}}
+	want = []Mismatch{{"4", 1, NewAnswer([]int{1, 2}), NewAnswer([]int{1, 3})}}
+	if mismatches := answers.Verify(sliced); !reflect.DeepEqual(mismatches, want) {
+		t.Fatalf("Verify(%v) = %v, want %v", sliced, mismatches, want)
+	}
+}
+
+type point struct {
+	X, Y int
+}
+
+func (p point) String() string {
+	return fmt.Sprintf("(%d,%d)", p.X, p.Y)
+}
+
+func TestAnswersVerifyKind(t *testing.T) {
+	answers := KnownAnswers{
+		"18": {NewAnswer(22), NewAnswer("(6,1)")},
+	}
+
+	res := Result{Name: "18", Part1: "22", Part2: "(6,1)", Answers: []Answer{NewAnswer(22), NewAnswer(point{6, 1})}}
+
+	mismatches := answers.Verify(res)
+	if len(mismatches) != 1 || mismatches[0].Part != 2 {
+		t.Fatalf("Verify(%v) = %v, want a kind mismatch for part 2", res, mismatches)
+	}
+
+	legacy := KnownAnswers{}
+	if err := json.Unmarshal([]byte(`{"18": {"part1": "22", "part2": "(6,1)"}}`), &legacy); err != nil {
+		t.Fatal(err)
+	}
+	if mismatches := legacy.Verify(res); len(mismatches) != 0 {
+		t.Fatalf("Verify(%v) with legacy answers = %v, want none", res, mismatches)
+	}
+}
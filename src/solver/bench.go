@@ -0,0 +1,243 @@
+package solver
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"log/slog"
+	"os"
+	"runtime"
+	"slices"
+	"text/tabwriter"
+	"time"
+)
+
+type (
+	// BenchOptions determines how often each part is run. When `Budget` is
+	// set, parts are repeated until the budget is used up, otherwise they are
+	// run `Runs` times. Every part runs at least once. `Params` are passed to
+	// the solver and its log messages go to `Logger`, or nowhere when nil.
+	BenchOptions struct {
+		Runs   int
+		Budget time.Duration
+		Params Params
+		Logger *slog.Logger
+	}
+
+	// BenchStats holds the timing and allocation statistics of a single part.
+	BenchStats struct {
+		Runs        int           `json:"runs"`
+		Min         time.Duration `json:"min"`
+		Median      time.Duration `json:"median"`
+		Mean        time.Duration `json:"mean"`
+		P95         time.Duration `json:"p95"`
+		AllocsPerOp uint64        `json:"allocs_per_op"`
+		BytesPerOp  uint64        `json:"bytes_per_op"`
+	}
+
+	// BenchResult holds the statistics for both parts of a day. Parts that
+	// are not implemented have zero `Runs`.
+	BenchResult struct {
+		Day   string     `json:"day"`
+		Part1 BenchStats `json:"part1"`
+		Part2 BenchStats `json:"part2"`
+	}
+
+	// Baseline maps days to previously saved benchmark results.
+	Baseline map[string]BenchResult
+
+	// Regression describes a part whose median got slower than allowed.
+	Regression struct {
+		Day      string
+		Part     int
+		Baseline time.Duration
+		Current  time.Duration
+	}
+)
+
+// Bench runs both parts of `s` repeatedly on `input` and collects statistics.
+func Bench(s Solver, input []string, bo BenchOptions) (BenchResult, error) {
+	res := BenchResult{Day: s.Day()}
+	parts := partFuncs(s)
+
+	logger := discardLogger
+	if bo.Logger != nil {
+		logger = bo.Logger
+	}
+	logger = logger.With("day", s.Day())
+
+	part1, err := benchPart(parts[0], input, Options{logger.With("part", 1), bo.Params}, bo)
+	if err != nil {
+		return res, fmt.Errorf("failed to bench Part1: %w", err)
+	}
+
+	part2, err := benchPart(parts[1], input, Options{logger.With("part", 2), bo.Params}, bo)
+	if err != nil {
+		return res, fmt.Errorf("failed to bench Part2: %w", err)
+	}
+
+	res.Part1 = part1
+	res.Part2 = part2
+
+	return res, nil
+}
+
+func benchPart(part partFunc, input []string, opts Options, bo BenchOptions) (BenchStats, error) {
+	ctx := context.Background()
+	durations := []time.Duration{}
+
+	var before, after runtime.MemStats
+	runtime.GC()
+	runtime.ReadMemStats(&before)
+
+	begin := time.Now()
+	for {
+		start := time.Now()
+		_, err := part(ctx, input, opts)
+		durations = append(durations, time.Since(start))
+
+		if errors.Is(err, ErrNotImplemented) {
+			return BenchStats{}, nil
+		}
+		if err != nil {
+			return BenchStats{}, err
+		}
+
+		if bo.Budget > 0 {
+			if time.Since(begin) >= bo.Budget {
+				break
+			}
+		} else if len(durations) >= bo.Runs {
+			break
+		}
+	}
+
+	runtime.ReadMemStats(&after)
+
+	return newBenchStats(durations, after.Mallocs-before.Mallocs, after.TotalAlloc-before.TotalAlloc), nil
+}
+
+func newBenchStats(durations []time.Duration, mallocs, bytes uint64) BenchStats {
+	sorted := slices.Clone(durations)
+	slices.Sort(sorted)
+
+	runs := len(sorted)
+
+	total := time.Duration(0)
+	for _, d := range sorted {
+		total += d
+	}
+
+	return BenchStats{
+		Runs:        runs,
+		Min:         sorted[0],
+		Median:      percentile(sorted, 50),
+		Mean:        total / time.Duration(runs),
+		P95:         percentile(sorted, 95),
+		AllocsPerOp: mallocs / uint64(runs),
+		BytesPerOp:  bytes / uint64(runs),
+	}
+}
+
+// percentile uses the nearest-rank method on sorted durations.
+func percentile(sorted []time.Duration, p int) time.Duration {
+	rank := (p*len(sorted) + 99) / 100
+	return sorted[max(rank, 1)-1]
+}
+
+// Stats returns the statistics of `part` (1 or 2).
+func (r BenchResult) Stats(part int) BenchStats {
+	if part == 2 {
+		return r.Part2
+	}
+	return r.Part1
+}
+
+// LoadBaseline reads a saved baseline from a JSON file at `path`. A missing
+// file results in an empty baseline.
+func LoadBaseline(path string) (Baseline, error) {
+	baseline := Baseline{}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return baseline, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read baseline: %w", err)
+	}
+
+	if err := json.Unmarshal(data, &baseline); err != nil {
+		return nil, fmt.Errorf("parse baseline %s: %w", path, err)
+	}
+
+	return baseline, nil
+}
+
+// SaveBaseline writes the baseline to a JSON file at `path`.
+func SaveBaseline(path string, baseline Baseline) error {
+	data, err := json.MarshalIndent(baseline, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode baseline: %w", err)
+	}
+
+	if err := os.WriteFile(path, append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("write baseline: %w", err)
+	}
+	return nil
+}
+
+// Compare returns the parts of `r` whose median is more than `threshold`
+// (a fraction, e.g. 0.1 for 10%) slower than in the baseline.
+func (b Baseline) Compare(r BenchResult, threshold float64) []Regression {
+	base, ok := b[r.Day]
+	if !ok {
+		return nil
+	}
+
+	regressions := []Regression{}
+	for part := 1; part <= 2; part++ {
+		old, cur := base.Stats(part), r.Stats(part)
+		if old.Runs == 0 || cur.Runs == 0 {
+			continue
+		}
+
+		if float64(cur.Median) > float64(old.Median)*(1+threshold) {
+			regressions = append(regressions, Regression{r.Day, part, old.Median, cur.Median})
+		}
+	}
+	return regressions
+}
+
+func (r Regression) Error() string {
+	return fmt.Sprintf("day %s part %d: median went from %v to %v", r.Day, r.Part, r.Baseline, r.Current)
+}
+
+// WriteBench writes the benchmark result as an aligned table, including the
+// change of the median compared to the baseline when there is one.
+func WriteBench(w io.Writer, r BenchResult, baseline Baseline) error {
+	tw := tabwriter.NewWriter(w, 0, 8, 2, ' ', tabwriter.AlignRight)
+
+	fmt.Fprintln(tw, "day\tpart\truns\tmin\tmedian\tmean\tp95\tallocs/op\tB/op\tvs baseline\t")
+
+	base, hasBase := baseline[r.Day]
+	for part := 1; part <= 2; part++ {
+		st := r.Stats(part)
+		if st.Runs == 0 {
+			fmt.Fprintf(tw, "%v\t%v\t0\t-\t-\t-\t-\t-\t-\t%v\t\n", r.Day, part, StatusNotImplemented)
+			continue
+		}
+
+		delta := "-"
+		if old := base.Stats(part); hasBase && old.Runs > 0 {
+			delta = fmt.Sprintf("%+.1f%%", 100*(float64(st.Median)/float64(old.Median)-1))
+		}
+
+		fmt.Fprintf(tw, "%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t\n",
+			r.Day, part, st.Runs, st.Min, st.Median, st.Mean, st.P95, st.AllocsPerOp, st.BytesPerOp, delta)
+	}
+
+	return tw.Flush()
+}
@@ -0,0 +1,67 @@
+package solver
+
+import (
+	"bytes"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNewBenchStats(t *testing.T) {
+	durations := []time.Duration{}
+	for n := 20; n > 0; n-- {
+		durations = append(durations, time.Duration(n)*time.Millisecond)
+	}
+
+	st := newBenchStats(durations, 40, 400)
+
+	want := BenchStats{
+		Runs:        20,
+		Min:         1 * time.Millisecond,
+		Median:      10 * time.Millisecond,
+		Mean:        10500 * time.Microsecond,
+		P95:         19 * time.Millisecond,
+		AllocsPerOp: 2,
+		BytesPerOp:  20,
+	}
+	if st != want {
+		t.Fatalf("newBenchStats() = %+v, want %+v", st, want)
+	}
+}
+
+func TestBaselineCompare(t *testing.T) {
+	baseline := Baseline{
+		"1": {"1", BenchStats{Runs: 1, Median: 100}, BenchStats{Runs: 1, Median: 100}},
+	}
+
+	current := BenchResult{"1", BenchStats{Runs: 1, Median: 105}, BenchStats{Runs: 1, Median: 120}}
+
+	regressions := baseline.Compare(current, 0.1)
+	want := Regression{"1", 2, 100, 120}
+	if len(regressions) != 1 || regressions[0] != want {
+		t.Fatalf("Compare() = %v, want [%v]", regressions, want)
+	}
+
+	if regressions := baseline.Compare(BenchResult{Day: "2"}, 0.1); len(regressions) != 0 {
+		t.Fatalf("Compare() without baseline = %v, want none", regressions)
+	}
+}
+
+func TestBenchLogs(t *testing.T) {
+	var buf bytes.Buffer
+	bo := BenchOptions{Runs: 1, Logger: NewLogger(&buf, slog.LevelDebug)}
+
+	if _, err := Bench(chattySolver{}, []string{"a", "b"}, bo); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, want := range []string{
+		`msg="counting 2 lines" day=chatty part=1`,
+		`msg=counting day=chatty part=2 lines=2`,
+	} {
+		if !strings.Contains(buf.String(), want) {
+			t.Fatalf("log = %q, want it to contain %q", buf.String(), want)
+		}
+	}
+}
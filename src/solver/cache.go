@@ -0,0 +1,143 @@
+package solver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+var (
+	// ErrNotCached returns when the requested puzzle input is not in the cache.
+	ErrNotCached = errors.New("puzzle input not cached")
+)
+
+// CacheMode determines how `GetCachedInput` uses the `InputCache`.
+type CacheMode int
+
+const (
+	// CacheUse returns cached input when available and stores freshly
+	// downloaded input.
+	CacheUse CacheMode = iota
+	// CacheRefresh always downloads the input and overwrites the cached copy.
+	CacheRefresh
+	// CacheBypass always downloads the input and leaves the cache untouched.
+	CacheBypass
+)
+
+// InputCache stores puzzle inputs on disk as `<Dir>/<day>.txt`.
+type InputCache struct {
+	Dir string
+}
+
+// DefaultCacheDir returns `$XDG_CACHE_HOME/aoc2024`, or the platform
+// equivalent when `XDG_CACHE_HOME` is not set.
+func DefaultCacheDir() (string, error) {
+	base, err := os.UserCacheDir()
+	if err != nil {
+		return "", fmt.Errorf("find user cache dir: %w", err)
+	}
+	return filepath.Join(base, "aoc2024"), nil
+}
+
+// NewInputCache creates an `InputCache` in `dir`. When `dir` is empty,
+// `DefaultCacheDir` is used.
+func NewInputCache(dir string) (InputCache, error) {
+	if dir == "" {
+		def, err := DefaultCacheDir()
+		if err != nil {
+			return InputCache{}, err
+		}
+		dir = def
+	}
+	return InputCache{dir}, nil
+}
+
+// ForYear returns the cache for the puzzles of `year`, which is a
+// subdirectory named after the year except for `DefaultYear`.
+func (c InputCache) ForYear(year string) InputCache {
+	return InputCache{YearDir(c.Dir, year)}
+}
+
+// Path returns the location of the cached input for day `d`.
+func (c InputCache) Path(d string) string {
+	return filepath.Join(c.Dir, d+".txt")
+}
+
+// Load retrieves the cached input for day `d`. Returns `ErrNotCached` when
+// there is none.
+func (c InputCache) Load(d string) ([]byte, error) {
+	data, err := os.ReadFile(c.Path(d))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("[%s]: %w", d, ErrNotCached)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read cached input: %w", err)
+	}
+	return data, nil
+}
+
+// Store writes the input for day `d` to the cache, replacing any previous
+// copy.
+func (c InputCache) Store(d string, data []byte) error {
+	return c.store(c.Path(d), data)
+}
+
+// store atomically replaces the file at `path` in the cache with `data`.
+func (c InputCache) store(path string, data []byte) error {
+	if err := os.MkdirAll(c.Dir, 0o755); err != nil {
+		return fmt.Errorf("create cache dir: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(c.Dir, filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("create cache file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("write cache file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("close cache file: %w", err)
+	}
+
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("store cache file: %w", err)
+	}
+	return nil
+}
+
+// GetCachedInput returns the puzzle input for day `d` in `year`, consulting
+// the part of `cache` for that year according to `mode` before falling back
+// to `GetInput`. Only successful downloads are stored, so `ErrNotFound` and
+// `ErrUnauthorized` responses never end up in the cache.
+func GetCachedInput(ctx context.Context, cache InputCache, mode CacheMode, year string, d string, session string) ([]byte, error) {
+	cache = cache.ForYear(year)
+
+	if mode == CacheUse {
+		data, err := cache.Load(d)
+		if err == nil {
+			return data, nil
+		}
+		if !errors.Is(err, ErrNotCached) || session == "" {
+			return nil, err
+		}
+	}
+
+	data, err := GetInput(ctx, year, d, session)
+	if err != nil {
+		return nil, err
+	}
+
+	if mode != CacheBypass {
+		if err := cache.Store(d, data); err != nil {
+			return nil, err
+		}
+	}
+
+	return data, nil
+}
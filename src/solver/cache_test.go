@@ -0,0 +1,161 @@
+package solver
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// redirectClient sends every request to the test server instead of the real
+// puzzle site.
+type redirectClient struct {
+	target *url.URL
+	calls  int
+}
+
+func (c *redirectClient) Do(req *http.Request) (*http.Response, error) {
+	c.calls++
+	req.URL.Scheme = c.target.Scheme
+	req.URL.Host = c.target.Host
+	return http.DefaultClient.Do(req)
+}
+
+func fakeSite(t *testing.T, handler http.HandlerFunc) *redirectClient {
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	target, err := url.Parse(srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	client := &redirectClient{target: target}
+	prev, prevSite := Client, Site
+	Client = client
+	Site = NewSiteClient(ClientConfig{Timeout: 5 * time.Second, UserAgent: DefaultUserAgent})
+	t.Cleanup(func() { Client, Site = prev, prevSite })
+
+	return client
+}
+
+func TestGetCachedInputStoresAndReuses(t *testing.T) {
+	client := fakeSite(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/2024/day/3/input" {
+			t.Errorf("unexpected path %q", r.URL.Path)
+		}
+		w.Write([]byte("puzzle\n"))
+	})
+	cache := InputCache{t.TempDir()}
+	ctx := context.Background()
+
+	for range 2 {
+		data, err := GetCachedInput(ctx, cache, CacheUse, "2024", "3", "sess")
+		if err != nil || string(data) != "puzzle\n" {
+			t.Fatalf("GetCachedInput() = %q, %v, want %q, %v", data, err, "puzzle\n", nil)
+		}
+	}
+
+	if client.calls != 1 {
+		t.Fatalf("expected 1 request, got %v", client.calls)
+	}
+}
+
+func TestGetCachedInputOtherYear(t *testing.T) {
+	fakeSite(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(r.URL.Path))
+	})
+	cache := InputCache{t.TempDir()}
+
+	data, err := GetCachedInput(context.Background(), cache, CacheUse, "2023", "3", "sess")
+	if err != nil || string(data) != "/2023/day/3/input" {
+		t.Fatalf("GetCachedInput(2023) = %q, %v, want %q, %v", data, err, "/2023/day/3/input", nil)
+	}
+
+	if _, err := os.Stat(filepath.Join(cache.Dir, "2023", "3.txt")); err != nil {
+		t.Fatalf("input of 2023 not cached in its own directory: %v", err)
+	}
+	if _, err := cache.Load("3"); !errors.Is(err, ErrNotCached) {
+		t.Fatalf("cache.Load(3) = %v, want %v for %v", err, ErrNotCached, DefaultYear)
+	}
+}
+
+func TestGetCachedInputModes(t *testing.T) {
+	client := fakeSite(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("fresh"))
+	})
+	cache := InputCache{t.TempDir()}
+	ctx := context.Background()
+
+	if err := cache.Store("5", []byte("stale")); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := GetCachedInput(ctx, cache, CacheBypass, "2024", "5", "sess")
+	if err != nil || string(data) != "fresh" {
+		t.Fatalf("bypass: got %q, %v, want %q", data, err, "fresh")
+	}
+	if cached, _ := cache.Load("5"); string(cached) != "stale" {
+		t.Fatalf("bypass should not touch the cache, got %q", cached)
+	}
+
+	data, err = GetCachedInput(ctx, cache, CacheRefresh, "2024", "5", "sess")
+	if err != nil || string(data) != "fresh" {
+		t.Fatalf("refresh: got %q, %v, want %q", data, err, "fresh")
+	}
+	if cached, _ := cache.Load("5"); string(cached) != "fresh" {
+		t.Fatalf("refresh should overwrite the cache, got %q", cached)
+	}
+
+	if client.calls != 2 {
+		t.Fatalf("expected 2 requests, got %v", client.calls)
+	}
+}
+
+func TestGetCachedInputDoesNotCacheErrors(t *testing.T) {
+	cases := []struct {
+		status int
+		want   error
+	}{
+		{http.StatusNotFound, ErrNotFound},
+		{http.StatusBadRequest, ErrUnauthorized},
+	}
+
+	for _, cs := range cases {
+		t.Run(http.StatusText(cs.status), func(t *testing.T) {
+			fakeSite(t, func(w http.ResponseWriter, r *http.Request) {
+				http.Error(w, "nope", cs.status)
+			})
+			cache := InputCache{t.TempDir()}
+
+			_, err := GetCachedInput(context.Background(), cache, CacheUse, "2024", "7", "sess")
+			if !errors.Is(err, cs.want) {
+				t.Fatalf("GetCachedInput() error = %v, want %v", err, cs.want)
+			}
+
+			if _, err := cache.Load("7"); !errors.Is(err, ErrNotCached) {
+				t.Fatalf("cache.Load() error = %v, want %v", err, ErrNotCached)
+			}
+		})
+	}
+}
+
+func TestGetCachedInputWithoutSession(t *testing.T) {
+	client := fakeSite(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("puzzle"))
+	})
+	cache := InputCache{t.TempDir()}
+
+	_, err := GetCachedInput(context.Background(), cache, CacheUse, "2024", "9", "")
+	if !errors.Is(err, ErrNotCached) {
+		t.Fatalf("GetCachedInput() error = %v, want %v", err, ErrNotCached)
+	}
+	if client.calls != 0 {
+		t.Fatalf("expected no requests, got %v", client.calls)
+	}
+}
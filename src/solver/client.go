@@ -0,0 +1,204 @@
+package solver
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// DefaultUserAgent identifies the requests of this tool to the puzzle site.
+// The site asks to include contact information, which is up to the user.
+const DefaultUserAgent = "github.com/wthys/advent-of-code-2024"
+
+// ClientConfig tunes how requests are sent to the puzzle site.
+type ClientConfig struct {
+	// Timeout limits every attempt of a request, including reading the body.
+	Timeout time.Duration
+	// Retries is the number of times a request is tried again after a
+	// network error or a server error.
+	Retries int
+	// Backoff is the wait before the first retry, doubled for every next one.
+	Backoff time.Duration
+	// Throttle is the minimum time between any two requests.
+	Throttle time.Duration
+	// UserAgent is sent with every request.
+	UserAgent string
+}
+
+// DefaultClientConfig returns the configuration used unless told otherwise.
+func DefaultClientConfig() ClientConfig {
+	return ClientConfig{
+		Timeout:   5 * time.Second,
+		Retries:   2,
+		Backoff:   time.Second,
+		Throttle:  500 * time.Millisecond,
+		UserAgent: DefaultUserAgent,
+	}
+}
+
+// SiteClient sends requests through a `ClientDo`, applying its
+// `ClientConfig`. Only idempotent requests are retried, so an answer is never
+// submitted twice. Responses come with their whole body already read.
+type SiteClient struct {
+	ClientConfig
+
+	// Client sends the requests, the package `Client` when nil.
+	Client ClientDo
+
+	mu   sync.Mutex
+	next time.Time
+}
+
+// Site is the client shared by everything that talks to the puzzle site.
+var Site = NewSiteClient(DefaultClientConfig())
+
+// NewSiteClient creates a `SiteClient` sending through the package `Client`.
+func NewSiteClient(cfg ClientConfig) *SiteClient {
+	return &SiteClient{ClientConfig: cfg}
+}
+
+// Configure replaces the configuration of the client.
+func (sc *SiteClient) Configure(cfg ClientConfig) {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+
+	sc.ClientConfig = cfg
+}
+
+func (sc *SiteClient) config() ClientConfig {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+
+	return sc.ClientConfig
+}
+
+func (sc *SiteClient) Do(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	cfg := sc.config()
+
+	for attempt := 0; ; attempt++ {
+		resp, err := sc.attempt(ctx, cfg, req)
+		if !retry(cfg, req, resp, err, attempt) {
+			return resp, err
+		}
+
+		wait := cfg.Backoff << attempt
+		LoggerFrom(ctx).Debug("retrying request", "url", req.URL, "attempt", attempt+1, "wait", wait, "error", err)
+
+		if err := sleep(ctx, wait); err != nil {
+			return nil, err
+		}
+	}
+}
+
+func (sc *SiteClient) attempt(ctx context.Context, cfg ClientConfig, req *http.Request) (*http.Response, error) {
+	if err := sc.throttle(ctx, cfg.Throttle); err != nil {
+		return nil, err
+	}
+
+	if cfg.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, cfg.Timeout)
+		defer cancel()
+	}
+
+	req = req.Clone(ctx)
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, fmt.Errorf("rewind request body: %w", err)
+		}
+		req.Body = body
+	}
+	if req.Header.Get("User-Agent") == "" && cfg.UserAgent != "" {
+		req.Header.Set("User-Agent", cfg.UserAgent)
+	}
+
+	client := sc.Client
+	if client == nil {
+		client = Client
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("read response body: %w", err)
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	return resp, nil
+}
+
+// retry tells whether another attempt is worth it. A 500 is not retried for
+// requests with a session, as that is how the site refuses a bad session.
+func retry(cfg ClientConfig, req *http.Request, resp *http.Response, err error, attempt int) bool {
+	if attempt >= cfg.Retries || req.Context().Err() != nil {
+		return false
+	}
+	if req.Method != http.MethodGet && req.Method != http.MethodHead {
+		return false
+	}
+	if err != nil {
+		return true
+	}
+	if resp.StatusCode == http.StatusInternalServerError && hasSession(req) {
+		return false
+	}
+	return resp.StatusCode >= 500
+}
+
+// throttle waits until `interval` has passed since the previous request.
+func (sc *SiteClient) throttle(ctx context.Context, interval time.Duration) error {
+	sc.mu.Lock()
+	now := time.Now()
+	wait := sc.next.Sub(now)
+	sc.next = now.Add(max(wait, 0) + interval)
+	sc.mu.Unlock()
+
+	return sleep(ctx, wait)
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+func hasSession(req *http.Request) bool {
+	cookie, err := req.Cookie("session")
+	return err == nil && cookie.Value != ""
+}
+
+// refused tells whether the site rejected the session of `req`: it answers
+// with a server error or redirects elsewhere, usually to the login page.
+func refused(req *http.Request, resp *http.Response) bool {
+	if !hasSession(req) {
+		return false
+	}
+	if resp.StatusCode == http.StatusInternalServerError {
+		return true
+	}
+	if resp.StatusCode/100 == 3 && resp.Header.Get("Location") != "" {
+		return true
+	}
+	return resp.Request != nil && resp.Request.URL.Path != req.URL.Path
+}
@@ -0,0 +1,103 @@
+package solver
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSiteClientRetries(t *testing.T) {
+	failures := 2
+	client := fakeSite(t, func(w http.ResponseWriter, r *http.Request) {
+		if failures > 0 {
+			failures--
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte("puzzle\n"))
+	})
+	Site.Configure(ClientConfig{Retries: 2, Backoff: time.Millisecond})
+
+	data, err := GetInput(context.Background(), "2024", "1", "session")
+	if err != nil || string(data) != "puzzle\n" || client.calls != 3 {
+		t.Fatalf("GetInput() = %q, %v after %v calls, want %q after 3 calls", data, err, client.calls, "puzzle\n")
+	}
+
+	failures = 3
+	client.calls = 0
+	if _, err := GetInput(context.Background(), "2024", "1", "session"); err == nil || client.calls != 3 {
+		t.Fatalf("GetInput() = %v after %v calls, want an error after 3 calls", err, client.calls)
+	}
+
+	failures = 1
+	client.calls = 0
+	if _, err := SubmitAnswer(context.Background(), "2024", "1", 1, "42", "session"); err == nil || client.calls != 1 {
+		t.Fatalf("SubmitAnswer() = %v after %v calls, want an error without retrying", err, client.calls)
+	}
+}
+
+func TestSiteClientRefusedSession(t *testing.T) {
+	client := fakeSite(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/2024/day/1/input":
+			w.WriteHeader(http.StatusInternalServerError)
+		case "/2024/leaderboard/private/view/1.json":
+			http.Redirect(w, r, "/2024/auth/login", http.StatusFound)
+		default:
+			w.Write([]byte("login"))
+		}
+	})
+	Site.Configure(ClientConfig{Retries: 2})
+
+	if _, err := GetInput(context.Background(), "2024", "1", "session"); !errors.Is(err, ErrUnauthorized) || client.calls != 1 {
+		t.Fatalf("GetInput() = %v after %v calls, want %v after 1 call", err, client.calls, ErrUnauthorized)
+	}
+
+	if _, err := GetLeaderboard(context.Background(), "2024", "1", "session"); !errors.Is(err, ErrUnauthorized) {
+		t.Fatalf("GetLeaderboard() = %v, want %v", err, ErrUnauthorized)
+	}
+}
+
+func TestSiteClientThrottleAndUserAgent(t *testing.T) {
+	agents := []string{}
+	fakeSite(t, func(w http.ResponseWriter, r *http.Request) {
+		agents = append(agents, r.UserAgent())
+	})
+	Site.Configure(ClientConfig{Throttle: 50 * time.Millisecond, UserAgent: "tester (me@example.com)"})
+
+	start := time.Now()
+	for range 3 {
+		if _, err := GetInput(context.Background(), "2024", "1", "session"); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if elapsed := time.Since(start); elapsed < 100*time.Millisecond {
+		t.Fatalf("3 requests took %v, want at least %v", elapsed, 100*time.Millisecond)
+	}
+	if strings.Join(agents, ",") != strings.Repeat("tester (me@example.com),", 2)+"tester (me@example.com)" {
+		t.Fatalf("user agents = %q, want the configured one", agents)
+	}
+}
+
+func TestRefusedWithoutSession(t *testing.T) {
+	req, _ := http.NewRequest(http.MethodGet, "https://adventofcode.com/2024/day/1", http.NoBody)
+	resp := &http.Response{StatusCode: http.StatusInternalServerError, Request: req}
+	if refused(req, resp) {
+		t.Fatalf("refused() without session = true, want false")
+	}
+
+	authorize(req, "session")
+	if !refused(req, resp) {
+		t.Fatalf("refused() of a 500 = false, want true")
+	}
+
+	resp = &http.Response{StatusCode: http.StatusOK, Request: &http.Request{URL: &url.URL{Path: "/2024/auth/login"}}}
+	if !refused(req, resp) {
+		t.Fatalf("refused() after a redirect = false, want true")
+	}
+}
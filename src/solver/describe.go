@@ -0,0 +1,238 @@
+package solver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"html"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// ErrNoDescription is returned when a page holds no puzzle description.
+var ErrNoDescription = errors.New("no puzzle description")
+
+// Description holds the articles describing the parts of a puzzle, as HTML.
+// The second part is only there once the first one is solved.
+type Description struct {
+	Day      string
+	Articles []string
+}
+
+var (
+	reBlock     = regexp.MustCompile(`(?s)<(h2|p|pre|ul)(?:\s[^>]*)?>(.*?)</(?:h2|p|pre|ul)>`)
+	reItem      = regexp.MustCompile(`(?s)<li[^>]*>(.*?)</li>`)
+	reCode      = regexp.MustCompile(`(?s)<code[^>]*>(.*?)</code>`)
+	reEmph      = regexp.MustCompile(`(?s)<em[^>]*>(.*?)</em>`)
+	reLink      = regexp.MustCompile(`(?s)<a [^>]*href="([^"]*)"[^>]*>(.*?)</a>`)
+	reCodeBlock = regexp.MustCompile(`(?s)<pre[^>]*>\s*<code[^>]*>(.*?)</code>\s*</pre>`)
+)
+
+// GetDescription downloads the puzzle page of day `d` in `year`. The session
+// is optional, without it only the first part is described.
+func GetDescription(ctx context.Context, year string, d string, session string) (string, error) {
+	u, err := puzzleURL(year, "day", d)
+	if err != nil {
+		return "", err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, http.NoBody)
+	if err != nil {
+		return "", fmt.Errorf("create description request: %w", err)
+	}
+
+	if session != "" {
+		authorize(req, session)
+	}
+
+	resp, body, err := fetch(ctx, req)
+	if err != nil {
+		return "", err
+	}
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return string(body), nil
+	case http.StatusNotFound:
+		return "", fmt.Errorf("[%s]: %w", d, ErrNotFound)
+	case http.StatusBadRequest:
+		return "", ErrUnauthorized
+	default:
+		return "", fmt.Errorf("[%s] failed to get description[%s]", d, resp.Status)
+	}
+}
+
+// ParseDescription extracts the articles from a puzzle page.
+func ParseDescription(d string, page string) (Description, error) {
+	desc := Description{Day: d}
+	for _, caps := range reArticle.FindAllStringSubmatch(page, -1) {
+		desc.Articles = append(desc.Articles, strings.TrimSpace(caps[1]))
+	}
+
+	if len(desc.Articles) == 0 {
+		return desc, fmt.Errorf("[%s]: %w", d, ErrNoDescription)
+	}
+	return desc, nil
+}
+
+// DescriptionPath returns the location of the cached description of day `d`.
+func (c InputCache) DescriptionPath(d string) string {
+	return filepath.Join(c.Dir, d+".html")
+}
+
+// GetCachedDescription returns the description of day `d` in `year`,
+// consulting the part of `cache` for that year according to `mode`. A cached description lacking the second part is
+// downloaded again when a session is given, as that part may have been
+// unlocked since.
+func GetCachedDescription(ctx context.Context, cache InputCache, mode CacheMode, year string, d string, session string) (Description, error) {
+	cache = cache.ForYear(year)
+
+	if mode == CacheUse {
+		page, err := os.ReadFile(cache.DescriptionPath(d))
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return Description{}, fmt.Errorf("read cached description: %w", err)
+		}
+		if err == nil {
+			desc, err := ParseDescription(d, string(page))
+			if err == nil && (len(desc.Articles) > 1 || session == "") {
+				return desc, nil
+			}
+		}
+	}
+
+	page, err := GetDescription(ctx, year, d, session)
+	if err != nil {
+		return Description{}, err
+	}
+
+	desc, err := ParseDescription(d, page)
+	if err != nil {
+		return desc, err
+	}
+
+	if mode != CacheBypass {
+		articles := []string{}
+		for _, article := range desc.Articles {
+			articles = append(articles, "<article>"+article+"</article>")
+		}
+		if err := cache.store(cache.DescriptionPath(d), []byte(strings.Join(articles, "\n"))); err != nil {
+			return desc, err
+		}
+	}
+
+	return desc, nil
+}
+
+// Examples returns the contents of the code blocks of all parts, which
+// usually hold the example input.
+func (desc Description) Examples() []string {
+	examples := []string{}
+	for _, article := range desc.Articles {
+		for _, caps := range reCodeBlock.FindAllStringSubmatch(article, -1) {
+			examples = append(examples, html.UnescapeString(reTag.ReplaceAllString(caps[1], "")))
+		}
+	}
+	return examples
+}
+
+// WriteText writes the description as plain text, with paragraphs wrapped at
+// `width` columns.
+func (desc Description) WriteText(w io.Writer, width int) error {
+	return desc.write(w, func(tag string, content string) string {
+		switch tag {
+		case "h2":
+			return inlineText(content)
+		case "pre":
+			return indent(html.UnescapeString(reTag.ReplaceAllString(content, "")), "    ")
+		case "ul":
+			items := []string{}
+			for _, caps := range reItem.FindAllStringSubmatch(content, -1) {
+				item := indent(wrap(inlineText(caps[1]), width-2), "  ")
+				items = append(items, "- "+item[2:])
+			}
+			return strings.Join(items, "\n")
+		default:
+			return wrap(inlineText(content), width)
+		}
+	})
+}
+
+// WriteMarkdown writes the description as markdown.
+func (desc Description) WriteMarkdown(w io.Writer) error {
+	return desc.write(w, func(tag string, content string) string {
+		switch tag {
+		case "h2":
+			return "## " + inlineMarkdown(content)
+		case "pre":
+			code := html.UnescapeString(reTag.ReplaceAllString(content, ""))
+			return "```\n" + strings.TrimRight(code, "\n") + "\n```"
+		case "ul":
+			items := []string{}
+			for _, caps := range reItem.FindAllStringSubmatch(content, -1) {
+				items = append(items, "- "+inlineMarkdown(caps[1]))
+			}
+			return strings.Join(items, "\n")
+		default:
+			return inlineMarkdown(content)
+		}
+	})
+}
+
+func (desc Description) write(w io.Writer, block func(tag string, content string) string) error {
+	blocks := []string{}
+	for _, article := range desc.Articles {
+		for _, caps := range reBlock.FindAllStringSubmatch(article, -1) {
+			blocks = append(blocks, strings.TrimRight(block(caps[1], caps[2]), "\n"))
+		}
+	}
+
+	_, err := fmt.Fprintln(w, strings.Join(blocks, "\n\n"))
+	return err
+}
+
+func inlineText(content string) string {
+	text := reTag.ReplaceAllString(content, "")
+	return html.UnescapeString(strings.TrimSpace(reSpace.ReplaceAllString(text, " ")))
+}
+
+func inlineMarkdown(content string) string {
+	md := reCode.ReplaceAllStringFunc(content, func(code string) string {
+		inner := reCode.FindStringSubmatch(code)[1]
+		return "`" + reTag.ReplaceAllString(inner, "") + "`"
+	})
+	md = reEmph.ReplaceAllString(md, "**$1**")
+	md = reLink.ReplaceAllString(md, "[$2]($1)")
+	md = strings.ReplaceAll(md, "](/", "](https://adventofcode.com/")
+	return inlineText(md)
+}
+
+// wrap breaks `text` into lines of at most `width` characters, only breaking
+// at spaces.
+func wrap(text string, width int) string {
+	lines := []string{}
+	line := ""
+	for _, word := range strings.Fields(text) {
+		if line != "" && len(line)+1+len(word) > width {
+			lines = append(lines, line)
+			line = ""
+		}
+		if line != "" {
+			line += " "
+		}
+		line += word
+	}
+	return strings.Join(append(lines, line), "\n")
+}
+
+func indent(text string, prefix string) string {
+	lines := strings.Split(strings.TrimRight(text, "\n"), "\n")
+	for idx, line := range lines {
+		lines[idx] = prefix + line
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,124 @@
+package solver
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+const puzzlePage = `<html><body><main>
+<article class="day-desc"><h2>--- Day 1: Historian Hysteria ---</h2>
+<p>The <em>Chief Historian</em> is always present for the big Christmas sleigh launch,
+but nobody has seen him in months! See <a href="/2024/about">about</a>.</p>
+<pre><code>3   4
+4   3
+</code></pre>
+<ul>
+<li>The first pair is <code>3</code> and <code>4</code>, a distance of <code><em>1</em></code>.</li>
+<li>Pairs &amp; distances.</li>
+</ul>
+</article>
+<p>Your puzzle answer was <code>11</code>.</p>
+<article class="day-desc"><h2 id="part2">--- Part Two ---</h2>
+<p>This time, figure out how often each number appears:</p>
+<pre><code>1 &lt; 2
+</code></pre>
+</article>
+</main></body></html>`
+
+func TestParseDescription(t *testing.T) {
+	desc, err := ParseDescription("1", puzzlePage)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(desc.Articles) != 2 {
+		t.Fatalf("ParseDescription() found %v articles, want 2", len(desc.Articles))
+	}
+
+	examples := desc.Examples()
+	want := []string{"3   4\n4   3\n", "1 < 2\n"}
+	if len(examples) != len(want) || examples[0] != want[0] || examples[1] != want[1] {
+		t.Fatalf("Examples() = %q, want %q", examples, want)
+	}
+
+	if _, err := ParseDescription("1", "<html></html>"); err == nil {
+		t.Fatalf("ParseDescription() without articles succeeded, want an error")
+	}
+}
+
+func TestDescriptionMarkdown(t *testing.T) {
+	desc, _ := ParseDescription("1", puzzlePage)
+
+	var sb strings.Builder
+	if err := desc.WriteMarkdown(&sb); err != nil {
+		t.Fatal(err)
+	}
+
+	want := "## --- Day 1: Historian Hysteria ---\n\n" +
+		"The **Chief Historian** is always present for the big Christmas sleigh launch, but nobody has seen him in months! See [about](https://adventofcode.com/2024/about).\n\n" +
+		"```\n3   4\n4   3\n```\n\n" +
+		"- The first pair is `3` and `4`, a distance of `1`.\n- Pairs & distances.\n\n" +
+		"## --- Part Two ---\n\n" +
+		"This time, figure out how often each number appears:\n\n" +
+		"```\n1 < 2\n```\n"
+	if sb.String() != want {
+		t.Fatalf("WriteMarkdown() =\n%s\nwant\n%s", sb.String(), want)
+	}
+}
+
+func TestDescriptionText(t *testing.T) {
+	desc, _ := ParseDescription("1", puzzlePage)
+
+	var sb strings.Builder
+	if err := desc.WriteText(&sb, 40); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, want := range []string{
+		"The Chief Historian is always present\nfor the big Christmas sleigh launch, but\n",
+		"\n    3   4\n    4   3\n",
+		"\n- The first pair is 3 and 4, a distance\n  of 1.\n- Pairs & distances.\n",
+	} {
+		if !strings.Contains(sb.String(), want) {
+			t.Fatalf("WriteText() =\n%s\nwant it to contain\n%s", sb.String(), want)
+		}
+	}
+}
+
+func TestGetCachedDescription(t *testing.T) {
+	parts := 1
+	client := fakeSite(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/2024/day/1" {
+			http.NotFound(w, r)
+			return
+		}
+		page := puzzlePage
+		if parts == 1 {
+			page = page[:strings.Index(page, `<article class="day-desc"><h2 id="part2">`)]
+		}
+		w.Write([]byte(page))
+	})
+
+	cache := InputCache{t.TempDir()}
+	ctx := context.Background()
+
+	desc, err := GetCachedDescription(ctx, cache, CacheUse, "2024", "1", "")
+	if err != nil || len(desc.Articles) != 1 {
+		t.Fatalf("GetCachedDescription() = %v articles, %v, want 1", len(desc.Articles), err)
+	}
+
+	if _, err := GetCachedDescription(ctx, cache, CacheUse, "2024", "1", ""); err != nil || client.calls != 1 {
+		t.Fatalf("GetCachedDescription() again made %v calls, %v, want 1 call", client.calls, err)
+	}
+
+	parts = 2
+	desc, err = GetCachedDescription(ctx, cache, CacheUse, "2024", "1", "session")
+	if err != nil || len(desc.Articles) != 2 || client.calls != 2 {
+		t.Fatalf("GetCachedDescription() with session = %v articles after %v calls, %v, want 2 articles after 2 calls", len(desc.Articles), client.calls, err)
+	}
+
+	if _, err := GetCachedDescription(ctx, cache, CacheUse, "2024", "1", "session"); err != nil || client.calls != 2 {
+		t.Fatalf("GetCachedDescription() with both parts cached made %v calls, %v, want 2", client.calls, err)
+	}
+}
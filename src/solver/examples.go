@@ -0,0 +1,153 @@
+package solver
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// ErrNoExample is returned when a day has no example input.
+var ErrNoExample = errors.New("no example")
+
+// Example is an example input of a puzzle together with its expected answers
+// and the parameters it needs, read from `dayN.txt` and `dayN.expected` in the
+// examples directory. Named examples live in `dayN-NAME.txt` and
+// `dayN-NAME.expected`. Parts without an expected answer are not checked.
+type Example struct {
+	Day    string
+	Name   string
+	Path   string
+	Part1  string
+	Part2  string
+	Params Params
+}
+
+func exampleBase(d string, name string) string {
+	if name == "" {
+		return fmt.Sprintf("day%s", d)
+	}
+	return fmt.Sprintf("day%s-%s", d, name)
+}
+
+// YearDir returns the directory holding the files of `year` inside `dir`:
+// `dir` itself for `DefaultYear` and a subdirectory named after the year
+// otherwise.
+func YearDir(dir string, year string) string {
+	if year == "" || year == DefaultYear {
+		return dir
+	}
+	return filepath.Join(dir, year)
+}
+
+// LoadExamples finds all examples of day `d` in `dir`, the unnamed one first
+// and the named ones in alphabetical order.
+func LoadExamples(dir string, d string) ([]Example, error) {
+	names := []string{}
+
+	if _, err := os.Stat(filepath.Join(dir, exampleBase(d, "")+".txt")); err == nil {
+		names = append(names, "")
+	}
+
+	prefix := exampleBase(d, "") + "-"
+	paths, err := filepath.Glob(filepath.Join(dir, prefix+"*.txt"))
+	if err != nil {
+		return nil, fmt.Errorf("find examples: %w", err)
+	}
+	slices.Sort(paths)
+	for _, path := range paths {
+		names = append(names, strings.TrimSuffix(strings.TrimPrefix(filepath.Base(path), prefix), ".txt"))
+	}
+
+	if len(names) == 0 {
+		return nil, fmt.Errorf("day %s: %w", d, ErrNoExample)
+	}
+
+	examples := []Example{}
+	for _, name := range names {
+		ex, err := LoadExample(dir, d, name)
+		if err != nil {
+			return nil, err
+		}
+		examples = append(examples, ex)
+	}
+	return examples, nil
+}
+
+// LoadExample finds the example of day `d` in `dir` called `name`, the empty
+// name being the unnamed example. The expected answers are optional.
+func LoadExample(dir string, d string, name string) (Example, error) {
+	base := filepath.Join(dir, exampleBase(d, name))
+	ex := Example{Day: d, Name: name, Path: base + ".txt", Params: Params{}}
+
+	if _, err := os.Stat(ex.Path); errors.Is(err, fs.ErrNotExist) {
+		return ex, fmt.Errorf("%w: %s", ErrNoExample, ex.Path)
+	} else if err != nil {
+		return ex, fmt.Errorf("find example: %w", err)
+	}
+
+	path := base + ".expected"
+	file, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return ex, nil
+	}
+	if err != nil {
+		return ex, fmt.Errorf("read expected answers: %w", err)
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for lineNo := 1; scanner.Scan(); lineNo++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		key, value, ok := strings.Cut(line, ":")
+		if !ok {
+			return ex, fmt.Errorf("%s:%d: expected 'key: value', got %q", path, lineNo, line)
+		}
+
+		key = strings.TrimSpace(key)
+		value = strings.TrimSpace(value)
+		if param, ok := strings.CutPrefix(key, "param "); ok {
+			ex.Params[strings.TrimSpace(param)] = value
+			continue
+		}
+
+		switch key {
+		case "part1":
+			ex.Part1 = value
+		case "part2":
+			ex.Part2 = value
+		default:
+			return ex, fmt.Errorf("%s:%d: unknown key %q", path, lineNo, key)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return ex, fmt.Errorf("read expected answers: %w", err)
+	}
+
+	return ex, nil
+}
+
+// String identifies the example, e.g. "day18" or "day17-quine".
+func (e Example) String() string {
+	return exampleBase(e.Day, e.Name)
+}
+
+// HasAnswers tells whether any of the parts has an expected answer.
+func (e Example) HasAnswers() bool {
+	return e.Part1 != "" || e.Part2 != ""
+}
+
+// Verify compares a result of the example with the expected answers.
+func (e Example) Verify(r Result) []Mismatch {
+	known := KnownAnswers{e.Day: {UntypedAnswer(e.Part1), UntypedAnswer(e.Part2)}}
+	return known.Verify(r)
+}
@@ -0,0 +1,106 @@
+package solver_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	_ "github.com/wthys/advent-of-code-2024/solutions"
+	"github.com/wthys/advent-of-code-2024/solver"
+)
+
+// examplesDir is relative to the solver package directory.
+var examplesDir = filepath.Join("..", "..", "examples")
+
+func TestExamples(t *testing.T) {
+	if _, err := os.Stat(examplesDir); err != nil {
+		t.Skipf("examples not available: %v", err)
+	}
+
+	for _, s := range solver.Solvers() {
+		name := "day" + s.Day()
+		if year := solver.YearOf(s); year != solver.DefaultYear {
+			name = year + "/" + name
+		}
+
+		t.Run(name, func(t *testing.T) {
+			dir := solver.YearDir(examplesDir, solver.YearOf(s))
+			examples, err := solver.LoadExamples(dir, s.Day())
+			if errors.Is(err, solver.ErrNoExample) {
+				t.Skip(err)
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			for _, ex := range examples {
+				name := ex.Name
+				if name == "" {
+					name = "default"
+				}
+				t.Run(name, func(t *testing.T) {
+					checkExample(t, s, ex)
+				})
+			}
+		})
+	}
+}
+
+func checkExample(t *testing.T, s solver.Solver, ex solver.Example) {
+	if !ex.HasAnswers() {
+		t.Skipf("%v has no expected answers", ex)
+	}
+
+	file, err := os.Open(ex.Path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+
+	ctx := context.WithValue(context.Background(), "timeout", 10*time.Second)
+	ctx = context.WithValue(ctx, "params", ex.Params)
+
+	res, err := solver.Solve(s, file, ctx)
+	if res.Name == "" {
+		t.Fatal(err)
+	}
+	if err != nil {
+		t.Log(err)
+	}
+
+	for _, m := range ex.Verify(res) {
+		t.Error(m)
+	}
+}
+
+func TestLoadExamples(t *testing.T) {
+	dir := t.TempDir()
+	os.WriteFile(filepath.Join(dir, "day1.txt"), []byte("3   4\n"), 0o644)
+	os.WriteFile(filepath.Join(dir, "day1.expected"), []byte("# comment\npart1: 11\n\npart2: 31\n"), 0o644)
+	os.WriteFile(filepath.Join(dir, "day1-small.txt"), []byte("3   4\n"), 0o644)
+	os.WriteFile(filepath.Join(dir, "day1-small.expected"), []byte("param size: 7\npart1: 1\n"), 0o644)
+	os.WriteFile(filepath.Join(dir, "day2.txt"), []byte("7 6 4\n"), 0o644)
+	os.WriteFile(filepath.Join(dir, "day2.expected"), []byte("part3: 1\n"), 0o644)
+
+	examples, err := solver.LoadExamples(dir, "1")
+	if err != nil || len(examples) != 2 {
+		t.Fatalf("LoadExamples(1) = %+v, %v, want 2 examples", examples, err)
+	}
+	if ex := examples[0]; ex.Name != "" || ex.Part1 != "11" || ex.Part2 != "31" {
+		t.Fatalf("LoadExamples(1)[0] = %+v, want answers 11 and 31", ex)
+	}
+	if ex := examples[1]; ex.Name != "small" || ex.Part1 != "1" || ex.Part2 != "" || ex.Params["size"] != "7" {
+		t.Fatalf("LoadExamples(1)[1] = %+v, want example small with size 7", ex)
+	}
+
+	if _, err := solver.LoadExamples(dir, "2"); err == nil {
+		t.Fatalf("LoadExamples(2) succeeded, want an error for an unknown key")
+	}
+
+	if _, err := solver.LoadExamples(dir, "3"); !errors.Is(err, solver.ErrNoExample) {
+		t.Fatalf("LoadExamples(3) = %v, want %v", err, solver.ErrNoExample)
+	}
+}
@@ -0,0 +1,153 @@
+package solver
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Format selects how results are written by `WriteResults`.
+type Format string
+
+const (
+	FormatText     Format = "text"
+	FormatJSON     Format = "json"
+	FormatCSV      Format = "csv"
+	FormatMarkdown Format = "markdown"
+)
+
+// Formats lists all supported formats.
+var Formats = []Format{FormatText, FormatJSON, FormatCSV, FormatMarkdown}
+
+type (
+	// Record is the stable, machine-readable form of a `Result`.
+	Record struct {
+		Day   string     `json:"day"`
+		Part1 PartRecord `json:"part1"`
+		Part2 PartRecord `json:"part2"`
+	}
+
+	// PartRecord is the machine-readable form of a single part of a `Result`.
+	PartRecord struct {
+		Answer    string `json:"answer"`
+		Kind      Kind   `json:"kind"`
+		Status    Status `json:"status"`
+		ElapsedNs int64  `json:"elapsed_ns"`
+		Error     string `json:"error,omitempty"`
+	}
+)
+
+// ParseFormat validates a format name.
+func ParseFormat(name string) (Format, error) {
+	for _, format := range Formats {
+		if string(format) == name {
+			return format, nil
+		}
+	}
+	return "", fmt.Errorf("unknown format %q", name)
+}
+
+// NewRecord converts a `Result` into its `Record`.
+func NewRecord(r Result) Record {
+	return Record{r.Name, newPartRecord(r, 1), newPartRecord(r, 2)}
+}
+
+func newPartRecord(r Result, part int) PartRecord {
+	rec := PartRecord{
+		Answer:    r.Answer(part),
+		Kind:      r.TypedAnswer(part).Kind(),
+		Status:    r.Status(part),
+		ElapsedNs: r.Duration(part).Nanoseconds(),
+	}
+	if err := r.Err(part); err != nil {
+		rec.Error = err.Error()
+	}
+	return rec
+}
+
+// WriteResults writes `results` to `w` in the given format. The text format
+// prints a single result as is and multiple results as a table.
+func WriteResults(w io.Writer, format Format, results []Result) error {
+	switch format {
+	case FormatText:
+		if len(results) == 1 {
+			_, err := fmt.Fprintln(w, results[0])
+			return err
+		}
+		return WriteTable(w, results)
+	case FormatJSON:
+		return writeJSON(w, results)
+	case FormatCSV:
+		return writeCSV(w, results)
+	case FormatMarkdown:
+		return writeMarkdown(w, results)
+	default:
+		return fmt.Errorf("unknown format %q", format)
+	}
+}
+
+func records(results []Result) []Record {
+	recs := make([]Record, 0, len(results))
+	for _, r := range results {
+		recs = append(recs, NewRecord(r))
+	}
+	return recs
+}
+
+func writeJSON(w io.Writer, results []Result) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(records(results))
+}
+
+func writeCSV(w io.Writer, results []Result) error {
+	cw := csv.NewWriter(w)
+
+	header := []string{"day"}
+	for _, part := range []string{"part1", "part2"} {
+		header = append(header, part, part+"_kind", part+"_status", part+"_elapsed_ns", part+"_error")
+	}
+	cw.Write(header)
+
+	for _, rec := range records(results) {
+		row := []string{rec.Day}
+		for _, part := range []PartRecord{rec.Part1, rec.Part2} {
+			row = append(row, part.Answer, string(part.Kind), string(part.Status), strconv.FormatInt(part.ElapsedNs, 10), part.Error)
+		}
+		cw.Write(row)
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+func writeMarkdown(w io.Writer, results []Result) error {
+	escape := strings.NewReplacer("|", `\|`, "\n", " ")
+
+	fmt.Fprintln(w, "| Day | Part 1 | Status 1 | Time 1 | Part 2 | Status 2 | Time 2 |")
+	fmt.Fprintln(w, "|----:|--------|----------|-------:|--------|----------|-------:|")
+
+	for _, r := range results {
+		row := []string{r.Name}
+		for part := 1; part <= 2; part++ {
+			status := string(r.Status(part))
+			if err := r.Err(part); err != nil {
+				status = fmt.Sprintf("%v: %v", status, err)
+			}
+			row = append(row, r.Answer(part), status, r.Duration(part).String())
+		}
+
+		for idx, cell := range row {
+			row[idx] = escape.Replace(cell)
+		}
+
+		if _, err := fmt.Fprintf(w, "| %v |\n", strings.Join(row, " | ")); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,102 @@
+package solver
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+func formatResults() []Result {
+	return []Result{
+		{
+			Name:     "1",
+			Part1:    "11",
+			Part2:    Unsolved,
+			Elapsed:  []time.Duration{time.Millisecond, time.Microsecond},
+			Statuses: []Status{StatusSolved, StatusNotImplemented},
+			Errors:   []error{nil, nil},
+			Answers:  []Answer{NewAnswer(11), NoAnswer},
+		},
+		FailedResult("2", errors.New("boom")),
+	}
+}
+
+func TestWriteResultsJSON(t *testing.T) {
+	out := strings.Builder{}
+	if err := WriteResults(&out, FormatJSON, formatResults()); err != nil {
+		t.Fatal(err)
+	}
+
+	recs := []Record{}
+	if err := json.Unmarshal([]byte(out.String()), &recs); err != nil {
+		t.Fatalf("invalid json %q: %v", out.String(), err)
+	}
+
+	want := []Record{
+		{"1", PartRecord{"11", KindInt, StatusSolved, 1000000, ""}, PartRecord{Unsolved, KindNone, StatusNotImplemented, 1000, ""}},
+		{"2", PartRecord{Unsolved, KindNone, StatusUnsolved, 0, "boom"}, PartRecord{Unsolved, KindNone, StatusUnsolved, 0, "boom"}},
+	}
+	if len(recs) != len(want) {
+		t.Fatalf("WriteResults(json) = %v, want %v", recs, want)
+	}
+	for idx, rec := range recs {
+		if rec != want[idx] {
+			t.Fatalf("WriteResults(json)[%v] = %v, want %v", idx, rec, want[idx])
+		}
+	}
+}
+
+func TestWriteResultsCSV(t *testing.T) {
+	out := strings.Builder{}
+	if err := WriteResults(&out, FormatCSV, formatResults()); err != nil {
+		t.Fatal(err)
+	}
+
+	want := strings.Join([]string{
+		"day,part1,part1_kind,part1_status,part1_elapsed_ns,part1_error,part2,part2_kind,part2_status,part2_elapsed_ns,part2_error",
+		"1,11,int,solved,1000000,,unsolved,,not implemented,1000,",
+		"2,unsolved,,unsolved,0,boom,unsolved,,unsolved,0,boom",
+		"",
+	}, "\n")
+	if out.String() != want {
+		t.Fatalf("WriteResults(csv) = %q, want %q", out.String(), want)
+	}
+}
+
+func TestWriteResultsMarkdown(t *testing.T) {
+	results := append(formatResults(), Result{
+		Name:     "3",
+		Part1:    "a|b",
+		Part2:    Unsolved,
+		Statuses: []Status{StatusSolved, StatusUnsolved},
+		Errors:   []error{nil, errors.New("bad\ninput")},
+	})
+
+	out := strings.Builder{}
+	if err := WriteResults(&out, FormatMarkdown, results); err != nil {
+		t.Fatal(err)
+	}
+
+	want, err := os.ReadFile("testdata/results.md")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if out.String() != string(want) {
+		t.Fatalf("WriteResults(markdown) =\n%s\nwant\n%s", out.String(), want)
+	}
+}
+
+func TestParseFormat(t *testing.T) {
+	for _, format := range Formats {
+		if parsed, err := ParseFormat(string(format)); parsed != format || err != nil {
+			t.Fatalf("ParseFormat(%q) = %q, %v, want %q, %v", format, parsed, err, format, nil)
+		}
+	}
+
+	if _, err := ParseFormat("xml"); err == nil {
+		t.Fatalf("ParseFormat(%q) should fail", "xml")
+	}
+}
@@ -2,6 +2,7 @@
 package solver
 
 import (
+	"bufio"
 	"context"
 	"errors"
 	"fmt"
@@ -9,12 +10,9 @@ import (
 	"net/http"
 	"net/url"
 	"path"
+	"strings"
 	"time"
-        "bufio"
-        "strings"
-        "unicode"
-
-	log "github.com/obalunenko/logger"
+	"unicode"
 )
 
 var (
@@ -24,107 +22,131 @@ var (
 	ErrUnauthorized = errors.New("unauthorized")
 )
 
-
 // ClientDo provides the interface for custom HTTP client implementations.
 type ClientDo interface {
 	Do(*http.Request) (*http.Response, error)
 }
 
-// Client is the default Client and is used by Get, Head, and Post.
+// Client sends the requests of `Site` to the network.
 var Client ClientDo = http.DefaultClient
 
 // Get returns puzzle input.
-func GetInput(ctx context.Context, d string, session string) ([]byte, error) {
-	req, err := createInputReq(ctx, d, session)
+func GetInput(ctx context.Context, year string, d string, session string) ([]byte, error) {
+	req, err := createInputReq(ctx, year, d, session)
 	if err != nil {
 		return nil, fmt.Errorf("create input request: %w", err)
 	}
 
-	const (
-		timeoutSecs = 5
-	)
-
-	ctx, cancel := context.WithTimeout(ctx, time.Second*timeoutSecs)
-	defer cancel()
+	resp, body, err := fetch(ctx, req)
+	if err != nil {
+		return nil, err
+	}
 
-	req = req.Clone(ctx)
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return body, nil
+	case http.StatusNotFound:
+		return nil, fmt.Errorf("[%s]: %w", d, ErrNotFound)
+	case http.StatusBadRequest:
+		return nil, ErrUnauthorized
+	default:
+		return nil, fmt.Errorf("[%s] failed to get puzzle input[%s]", d, resp.Status)
+	}
+}
 
-	resp, err := Client.Do(req)
+// fetch sends `req` through `Site` and reads the whole response body. A
+// session refused by the site is reported as `ErrUnauthorized`.
+func fetch(ctx context.Context, req *http.Request) (*http.Response, []byte, error) {
+	resp, err := Site.Do(req.WithContext(ctx))
 	if err != nil {
-		return nil, fmt.Errorf("send request: %w", err)
+		return nil, nil, err
 	}
 
 	defer func() {
 		if err = resp.Body.Close(); err != nil {
-			log.WithError(ctx, err).Error("Failed to close body")
+			LoggerFrom(ctx).Error("Failed to close body", "error", err)
 		}
 	}()
 
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return nil, fmt.Errorf("read responsse body: %w", err)
+		return nil, nil, fmt.Errorf("read responsse body: %w", err)
 	}
 
-	switch resp.StatusCode {
-	case http.StatusOK:
-		return body, nil
-	case http.StatusNotFound:
-		return nil, fmt.Errorf("[%s]: %w", d, ErrNotFound)
-	case http.StatusBadRequest:
-		return nil, ErrUnauthorized
-	default:
-		return nil, fmt.Errorf("[%s] failed to get puzzle input[%s]", d, resp.Status)
+	if refused(req, resp) {
+		return nil, nil, ErrUnauthorized
 	}
-}
 
+	return resp, body, nil
+}
 
 func ReadLines(r io.Reader) ([]string, error) {
-    rdr := bufio.NewReader(r)
+	rdr := bufio.NewReader(r)
 
-    var lines = make([]string, 0)
+	var lines = make([]string, 0)
 
-    const newline = byte('\n')
+	const newline = byte('\n')
 
-    line, err := rdr.ReadString(newline)
+	line, err := rdr.ReadString(newline)
 
-    for err == nil {
-        lines = append(lines, strings.TrimRightFunc(line, unicode.IsSpace))
+	for err == nil {
+		lines = append(lines, strings.TrimRightFunc(line, unicode.IsSpace))
 
-        line, err = rdr.ReadString(newline)
-    }
+		line, err = rdr.ReadString(newline)
+	}
 
-    switch {
-        case err == io.EOF:
-			lines = append(lines, strings.TrimRightFunc(line, unicode.IsSpace))
-			return lines, nil
-        case err != nil:
-            return []string{}, err
-        default:
-            return lines, nil
-    }
+	switch {
+	case err == io.EOF:
+		lines = append(lines, strings.TrimRightFunc(line, unicode.IsSpace))
+		return lines, nil
+	case err != nil:
+		return []string{}, err
+	default:
+		return lines, nil
+	}
 }
 
 // createInputReq creates an HTTP request for retrieving the Advent of Code
 // input given year/day.
-func createInputReq(ctx context.Context, d string, sessionID string) (*http.Request, error) {
+func createInputReq(ctx context.Context, year string, d string, sessionID string) (*http.Request, error) {
 	const (
-		baseurl = "https://adventofcode.com"
-		day     = "day"
-		input   = "input"
+		day   = "day"
+		input = "input"
 	)
 
-	u, err := url.Parse(baseurl)
+	u, err := puzzleURL(year, day, d, input)
 	if err != nil {
-		return nil, fmt.Errorf("parse base url: %w", err)
+		return nil, err
 	}
 
-	u.Path = path.Join(u.Path, "2024", day, d, input)
-
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), http.NoBody)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, http.NoBody)
 	if err != nil {
 		return nil, err
 	}
 
+	authorize(req, sessionID)
+
+	return req, nil
+}
+
+// puzzleURL joins the path elements onto the Advent of Code base url.
+func puzzleURL(elems ...string) (string, error) {
+	const (
+		baseurl = "https://adventofcode.com"
+	)
+
+	u, err := url.Parse(baseurl)
+	if err != nil {
+		return "", fmt.Errorf("parse base url: %w", err)
+	}
+
+	u.Path = path.Join(append([]string{u.Path}, elems...)...)
+
+	return u.String(), nil
+}
+
+// authorize adds the session cookie to an Advent of Code request.
+func authorize(req *http.Request, sessionID string) {
 	req.AddCookie(&http.Cookie{
 		Name:       "session",
 		Value:      sessionID,
@@ -139,9 +161,4 @@ func createInputReq(ctx context.Context, d string, sessionID string) (*http.Requ
 		Raw:        "",
 		Unparsed:   nil,
 	})
-
-	req.Header.Set("User-Agent",
-		"Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/96.0.4664.93 Safari/537.36 (github.com/wthys/advent-of-code-2024 by wim.thys@zardof.be)")
-
-	return req, nil
 }
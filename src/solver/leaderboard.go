@@ -0,0 +1,285 @@
+package solver
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+// LeaderboardTTL is how long a downloaded leaderboard is used before it is
+// fetched again, as the site asks not to request it more often.
+const LeaderboardTTL = 15 * time.Minute
+
+type (
+	// Leaderboard is a private leaderboard as served by the site.
+	Leaderboard struct {
+		OwnerID int               `json:"owner_id"`
+		Event   string            `json:"event"`
+		Members map[string]Member `json:"members"`
+	}
+
+	// Member is a participant of a leaderboard. `Days` maps days to the
+	// stars earned for each part.
+	Member struct {
+		ID          int                        `json:"id"`
+		Name        string                     `json:"name"`
+		Stars       int                        `json:"stars"`
+		LocalScore  int                        `json:"local_score"`
+		GlobalScore int                        `json:"global_score"`
+		LastStarTs  int64                      `json:"last_star_ts"`
+		Days        map[string]map[string]Star `json:"completion_day_level"`
+	}
+
+	// Star tells when a part was solved.
+	Star struct {
+		GetStarTs int64 `json:"get_star_ts"`
+		StarIndex int64 `json:"star_index"`
+	}
+)
+
+// GetLeaderboard downloads private leaderboard `id` of `year`.
+func GetLeaderboard(ctx context.Context, year string, id string, session string) ([]byte, error) {
+	u, err := puzzleURL(year, "leaderboard", "private", "view", id+".json")
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, http.NoBody)
+	if err != nil {
+		return nil, fmt.Errorf("create leaderboard request: %w", err)
+	}
+
+	authorize(req, session)
+
+	resp, body, err := fetch(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return body, nil
+	case http.StatusNotFound:
+		return nil, fmt.Errorf("leaderboard %s: %w", id, ErrNotFound)
+	case http.StatusBadRequest:
+		return nil, ErrUnauthorized
+	default:
+		return nil, fmt.Errorf("leaderboard %s: failed to get leaderboard[%s]", id, resp.Status)
+	}
+}
+
+// ParseLeaderboard reads a leaderboard from its JSON.
+func ParseLeaderboard(data []byte) (Leaderboard, error) {
+	var lb Leaderboard
+	if err := json.Unmarshal(data, &lb); err != nil {
+		return lb, fmt.Errorf("parse leaderboard: %w", err)
+	}
+	return lb, nil
+}
+
+// LeaderboardPath returns the location of cached leaderboard `id`.
+func (c InputCache) LeaderboardPath(id string) string {
+	return filepath.Join(c.Dir, "leaderboard-"+id+".json")
+}
+
+// GetCachedLeaderboard returns private leaderboard `id` of `year`, consulting
+// the part of `cache` for that year according to `mode`. A cached
+// leaderboard is used as long as it is younger than `LeaderboardTTL`, in
+// every mode, so the site is never asked more often. A downloaded leaderboard
+// is always stored for that reason, also when bypassing the cache.
+func GetCachedLeaderboard(ctx context.Context, cache InputCache, mode CacheMode, year string, id string, session string) (Leaderboard, error) {
+	cache = cache.ForYear(year)
+	path := cache.LeaderboardPath(id)
+
+	info, err := os.Stat(path)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return Leaderboard{}, fmt.Errorf("find cached leaderboard: %w", err)
+	}
+
+	// a stale copy is better than nothing without a session, unless the
+	// cache is bypassed or refreshed
+	fresh := err == nil && time.Since(info.ModTime()) < LeaderboardTTL
+	stale := err == nil && mode == CacheUse && session == ""
+
+	if fresh || stale {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return Leaderboard{}, fmt.Errorf("read cached leaderboard: %w", err)
+		}
+		return ParseLeaderboard(data)
+	}
+
+	if session == "" {
+		return Leaderboard{}, fmt.Errorf("leaderboard %s: %w", id, ErrUnauthorized)
+	}
+
+	data, err := GetLeaderboard(ctx, year, id, session)
+	if err != nil {
+		return Leaderboard{}, err
+	}
+
+	lb, err := ParseLeaderboard(data)
+	if err != nil {
+		return lb, err
+	}
+
+	if err := cache.store(path, data); err != nil {
+		return lb, err
+	}
+
+	return lb, nil
+}
+
+// DisplayName returns the name of the member, or how the site shows
+// anonymous members.
+func (m Member) DisplayName() string {
+	if m.Name == "" {
+		return fmt.Sprintf("(anonymous user #%d)", m.ID)
+	}
+	return m.Name
+}
+
+// StarTime returns when the member solved `part` of `day`, if they did.
+func (m Member) StarTime(day int, part int) (time.Time, bool) {
+	star, ok := m.Days[strconv.Itoa(day)][strconv.Itoa(part)]
+	if !ok {
+		return time.Time{}, false
+	}
+	return time.Unix(star.GetStarTs, 0), true
+}
+
+// Ranking returns the members ordered by local score, those who got their
+// last star first coming first on a tie.
+func (lb Leaderboard) Ranking() []Member {
+	members := []Member{}
+	for _, m := range lb.Members {
+		members = append(members, m)
+	}
+
+	slices.SortFunc(members, func(a, b Member) int {
+		if a.LocalScore != b.LocalScore {
+			return b.LocalScore - a.LocalScore
+		}
+		if a.LastStarTs != b.LastStarTs {
+			return int(a.LastStarTs - b.LastStarTs)
+		}
+		return a.ID - b.ID
+	})
+
+	return members
+}
+
+// Days returns the number of the last day for which any member has a star.
+func (lb Leaderboard) Days() int {
+	last := 0
+	for _, m := range lb.Members {
+		for day := range m.Days {
+			if n, err := strconv.Atoi(day); err == nil && n > last {
+				last = n
+			}
+		}
+	}
+	return last
+}
+
+// Unlock returns when `day` of the leaderboard's event was unlocked, at
+// midnight in the US Eastern timezone.
+func (lb Leaderboard) Unlock(day int) time.Time {
+	year, _ := strconv.Atoi(lb.Event)
+	return time.Date(year, time.December, day, 5, 0, 0, 0, time.UTC)
+}
+
+// WriteLeaderboard writes the ranking with the score, the number of stars and
+// the stars of each day of every member. Both stars are shown as "*", only the
+// first one as "+".
+func WriteLeaderboard(w io.Writer, lb Leaderboard) error {
+	tw := tabwriter.NewWriter(w, 0, 8, 1, ' ', 0)
+
+	days := lb.Days()
+
+	header := []string{"#", "score", "stars", ""}
+	for day := 1; day <= days; day++ {
+		header[3] += strconv.Itoa(day % 10)
+	}
+	fmt.Fprintln(tw, strings.Join(append(header, "name"), "\t"))
+
+	for rank, m := range lb.Ranking() {
+		stars := ""
+		for day := 1; day <= days; day++ {
+			_, first := m.StarTime(day, 1)
+			_, second := m.StarTime(day, 2)
+			switch {
+			case second:
+				stars += "*"
+			case first:
+				stars += "+"
+			default:
+				stars += "."
+			}
+		}
+
+		fmt.Fprintf(tw, "%d)\t%d\t%d\t%s\t%s\n", rank+1, m.LocalScore, m.Stars, stars, m.DisplayName())
+	}
+
+	return tw.Flush()
+}
+
+// WriteLeaderboardDay writes when every member who solved a part of `day`
+// did so, how long after the unlock that was and how long the second part
+// took, fastest first.
+func WriteLeaderboardDay(w io.Writer, lb Leaderboard, day int) error {
+	unlock := lb.Unlock(day)
+
+	members := []Member{}
+	for _, m := range lb.Ranking() {
+		if _, ok := m.StarTime(day, 1); ok {
+			members = append(members, m)
+		}
+	}
+
+	// finish time of the last part solved, members with both parts first
+	finish := func(m Member) (int, time.Time) {
+		if t, ok := m.StarTime(day, 2); ok {
+			return 0, t
+		}
+		t, _ := m.StarTime(day, 1)
+		return 1, t
+	}
+	slices.SortStableFunc(members, func(a, b Member) int {
+		pa, ta := finish(a)
+		pb, tb := finish(b)
+		if pa != pb {
+			return pa - pb
+		}
+		return ta.Compare(tb)
+	})
+
+	tw := tabwriter.NewWriter(w, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(tw, "name\tpart1\tafter\tpart2\tafter\tdelta")
+
+	for _, m := range members {
+		first, _ := m.StarTime(day, 1)
+		row := []string{m.DisplayName(), first.Local().Format(time.DateTime), first.Sub(unlock).String(), "-", "-", "-"}
+
+		if second, ok := m.StarTime(day, 2); ok {
+			row[3] = second.Local().Format(time.DateTime)
+			row[4] = second.Sub(unlock).String()
+			row[5] = second.Sub(first).String()
+		}
+
+		fmt.Fprintln(tw, strings.Join(row, "\t"))
+	}
+
+	return tw.Flush()
+}
@@ -0,0 +1,155 @@
+package solver
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+// unlock of day 1 of 2024 is 1733029200
+const leaderboardJSON = `{"owner_id": 1, "event": "2024", "members": {
+"1": {"id": 1, "name": "alice", "stars": 3, "local_score": 8, "global_score": 0, "last_star_ts": 1733115900,
+	"completion_day_level": {
+		"1": {"1": {"get_star_ts": 1733029500, "star_index": 1}, "2": {"get_star_ts": 1733029800, "star_index": 2}},
+		"2": {"1": {"get_star_ts": 1733115900, "star_index": 5}}}},
+"2": {"id": 2, "name": null, "stars": 2, "local_score": 8, "global_score": 0, "last_star_ts": 1733029700,
+	"completion_day_level": {
+		"1": {"1": {"get_star_ts": 1733029260, "star_index": 0}, "2": {"get_star_ts": 1733029700, "star_index": 3}}}},
+"3": {"id": 3, "name": "carol", "stars": 0, "local_score": 0, "global_score": 0, "last_star_ts": 0,
+	"completion_day_level": {}}
+}}`
+
+func TestLeaderboardRanking(t *testing.T) {
+	lb, err := ParseLeaderboard([]byte(leaderboardJSON))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	names := []string{}
+	for _, m := range lb.Ranking() {
+		names = append(names, m.DisplayName())
+	}
+	want := []string{"(anonymous user #2)", "alice", "carol"}
+	if strings.Join(names, ",") != strings.Join(want, ",") {
+		t.Fatalf("Ranking() = %v, want %v", names, want)
+	}
+
+	if days := lb.Days(); days != 2 {
+		t.Fatalf("Days() = %v, want 2", days)
+	}
+
+	if unlock := lb.Unlock(1); unlock.Unix() != 1733029200 {
+		t.Fatalf("Unlock(1) = %v, want %v", unlock, time.Unix(1733029200, 0).UTC())
+	}
+}
+
+func TestWriteLeaderboard(t *testing.T) {
+	lb, _ := ParseLeaderboard([]byte(leaderboardJSON))
+
+	var sb strings.Builder
+	if err := WriteLeaderboard(&sb, lb); err != nil {
+		t.Fatal(err)
+	}
+
+	want := "#  score stars 12 name\n" +
+		"1) 8     2     *. (anonymous user #2)\n" +
+		"2) 8     3     *+ alice\n" +
+		"3) 0     0     .. carol\n"
+	if sb.String() != want {
+		t.Fatalf("WriteLeaderboard() =\n%s\nwant\n%s", sb.String(), want)
+	}
+}
+
+func TestWriteLeaderboardDay(t *testing.T) {
+	lb, _ := ParseLeaderboard([]byte(leaderboardJSON))
+
+	var sb strings.Builder
+	if err := WriteLeaderboardDay(&sb, lb, 1); err != nil {
+		t.Fatal(err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(sb.String()), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("WriteLeaderboardDay() =\n%s\nwant a header and 2 members", sb.String())
+	}
+	for idx, want := range [][]string{{"(anonymous user #2)", "1m0s", "8m20s", "7m20s"}, {"alice", "5m0s", "10m0s", "5m0s"}} {
+		for _, field := range want {
+			if !strings.Contains(lines[idx+1], field) {
+				t.Fatalf("WriteLeaderboardDay() line %v = %q, want it to contain %q", idx+1, lines[idx+1], field)
+			}
+		}
+	}
+
+	sb.Reset()
+	if err := WriteLeaderboardDay(&sb, lb, 2); err != nil {
+		t.Fatal(err)
+	}
+	if lines := strings.Split(strings.TrimSpace(sb.String()), "\n"); len(lines) != 2 || strings.Count(lines[1], " -") != 3 {
+		t.Fatalf("WriteLeaderboardDay() =\n%s\nwant only alice without a second part", sb.String())
+	}
+}
+
+func TestGetCachedLeaderboard(t *testing.T) {
+	client := fakeSite(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/2024/leaderboard/private/view/1.json" {
+			http.NotFound(w, r)
+			return
+		}
+		if cookie, err := r.Cookie("session"); err != nil || cookie.Value != "session" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.Write([]byte(leaderboardJSON))
+	})
+
+	cache := InputCache{t.TempDir()}
+	ctx := context.Background()
+
+	if _, err := GetCachedLeaderboard(ctx, cache, CacheUse, "2024", "1", ""); !errors.Is(err, ErrUnauthorized) {
+		t.Fatalf("GetCachedLeaderboard() without session = %v, want %v", err, ErrUnauthorized)
+	}
+
+	lb, err := GetCachedLeaderboard(ctx, cache, CacheUse, "2024", "1", "session")
+	if err != nil || len(lb.Members) != 3 || client.calls != 1 {
+		t.Fatalf("GetCachedLeaderboard() = %v members after %v calls, %v, want 3 after 1 call", len(lb.Members), client.calls, err)
+	}
+
+	for _, mode := range []CacheMode{CacheUse, CacheRefresh, CacheBypass} {
+		if _, err := GetCachedLeaderboard(ctx, cache, mode, "2024", "1", "session"); err != nil || client.calls != 1 {
+			t.Fatalf("GetCachedLeaderboard(%v) within %v made %v calls, %v, want 1", mode, LeaderboardTTL, client.calls, err)
+		}
+	}
+
+	stale := time.Now().Add(-LeaderboardTTL)
+	if err := os.Chtimes(cache.LeaderboardPath("1"), stale, stale); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := GetCachedLeaderboard(ctx, cache, CacheUse, "2024", "1", ""); err != nil || client.calls != 1 {
+		t.Fatalf("GetCachedLeaderboard() of a stale copy without session made %v calls, %v, want 1", client.calls, err)
+	}
+
+	if _, err := GetCachedLeaderboard(ctx, cache, CacheUse, "2024", "1", "session"); err != nil || client.calls != 2 {
+		t.Fatalf("GetCachedLeaderboard() of a stale copy made %v calls, %v, want 2", client.calls, err)
+	}
+
+	stale = time.Now().Add(-LeaderboardTTL)
+	if err := os.Chtimes(cache.LeaderboardPath("1"), stale, stale); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := GetCachedLeaderboard(ctx, cache, CacheBypass, "2024", "1", "session"); err != nil || client.calls != 3 {
+		t.Fatalf("GetCachedLeaderboard(%v) of a stale copy made %v calls, %v, want 3", CacheBypass, client.calls, err)
+	}
+	if _, err := GetCachedLeaderboard(ctx, cache, CacheBypass, "2024", "1", "session"); err != nil || client.calls != 3 {
+		t.Fatalf("GetCachedLeaderboard(%v) right after a download made %v calls, %v, want 3", CacheBypass, client.calls, err)
+	}
+
+	if _, err := GetCachedLeaderboard(ctx, cache, CacheUse, "2024", "2", "session"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("GetCachedLeaderboard() of unknown leaderboard = %v, want %v", err, ErrNotFound)
+	}
+}
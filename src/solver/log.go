@@ -0,0 +1,68 @@
+package solver
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+)
+
+// Logging is built on log/slog instead of github.com/obalunenko/logger, which
+// was only used for a few fatal errors: slog has levels and structured fields,
+// its handlers write to any io.Writer for --log-file and `LogWriter`, and it
+// needs no dependency.
+
+var discardLogger = slog.New(slog.DiscardHandler)
+
+// DefaultLogLevel hides everything but warnings and errors.
+const DefaultLogLevel = slog.LevelWarn
+
+// ParseLogLevel validates a level name, one of "debug", "info", "warn" or
+// "error".
+func ParseLogLevel(name string) (slog.Level, error) {
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(strings.TrimSpace(name))); err != nil {
+		return level, fmt.Errorf("unknown log level %q", name)
+	}
+	return level, nil
+}
+
+// logHandler remembers the writer of a text handler, which `LogWriter` hands
+// out.
+type logHandler struct {
+	slog.Handler
+	w io.Writer
+}
+
+func (h logHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return logHandler{h.Handler.WithAttrs(attrs), h.w}
+}
+
+func (h logHandler) WithGroup(name string) slog.Handler {
+	return logHandler{h.Handler.WithGroup(name), h.w}
+}
+
+// NewLogger creates a logger writing lines of key=value pairs to `w`,
+// dropping messages below `level`.
+func NewLogger(w io.Writer, level slog.Level) *slog.Logger {
+	return slog.New(logHandler{slog.NewTextHandler(w, &slog.HandlerOptions{Level: level}), w})
+}
+
+// LogWriter returns the writer of a logger created by `NewLogger`, or
+// `io.Discard` for any other logger.
+func LogWriter(logger *slog.Logger) io.Writer {
+	if h, ok := logger.Handler().(logHandler); ok {
+		return h.w
+	}
+	return io.Discard
+}
+
+// LoggerFrom returns the logger stored in `ctx` under "logger", or a logger
+// that discards everything.
+func LoggerFrom(ctx context.Context) *slog.Logger {
+	if logger, ok := ctx.Value("logger").(*slog.Logger); ok && logger != nil {
+		return logger
+	}
+	return discardLogger
+}
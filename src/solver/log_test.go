@@ -0,0 +1,90 @@
+package solver
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+type chattySolver struct{}
+
+func (s chattySolver) Day() string { return "chatty" }
+
+func (s chattySolver) Part1(input []string, opts Options) (string, error) {
+	opts.Debugf("counting %v lines\n", len(input))
+	return Solved(len(input))
+}
+
+func (s chattySolver) Part2(input []string, opts Options) (string, error) {
+	opts.With("lines", len(input)).Debug("counting")
+	return Solved(len(input))
+}
+
+func TestAddAnswersLogs(t *testing.T) {
+	var buf bytes.Buffer
+	ctx := context.WithValue(context.Background(), "logger", NewLogger(&buf, slog.LevelDebug))
+
+	res := Result{Name: "chatty"}
+	if err := res.AddAnswers(chattySolver{}, []string{"a", "b"}, ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, want := range []string{
+		`msg="counting 2 lines" day=chatty part=1`,
+		`msg=counting day=chatty part=2 lines=2`,
+	} {
+		if !strings.Contains(buf.String(), want) {
+			t.Fatalf("log = %q, want it to contain %q", buf.String(), want)
+		}
+	}
+}
+
+func TestOptionsDiscardByDefault(t *testing.T) {
+	opts := DefaultOptions()
+	if opts.IsDebug() {
+		t.Fatalf("DefaultOptions().IsDebug() = true, want false")
+	}
+
+	var buf bytes.Buffer
+	ctx := context.WithValue(context.Background(), "logger", NewLogger(&buf, DefaultLogLevel))
+
+	res := Result{Name: "chatty"}
+	if err := res.AddAnswers(chattySolver{}, []string{"a"}, ctx); err != nil {
+		t.Fatal(err)
+	}
+	if buf.Len() != 0 {
+		t.Fatalf("log = %q, want nothing at level %v", buf.String(), DefaultLogLevel)
+	}
+}
+
+func TestIfDebugDo(t *testing.T) {
+	var buf bytes.Buffer
+	opts := Options{NewLogger(&buf, slog.LevelDebug), nil}.With("day", "1")
+
+	opts.IfDebugDo(func(w io.Writer) {
+		fmt.Fprint(w, "#.#\n")
+	})
+	if buf.String() != "#.#\n" {
+		t.Fatalf("log = %q, want the grid written by IfDebugDo", buf.String())
+	}
+
+	quiet := Options{NewLogger(&buf, DefaultLogLevel), nil}
+	quiet.IfDebugDo(func(_ io.Writer) {
+		t.Fatalf("IfDebugDo() called the printer at level %v", DefaultLogLevel)
+	})
+}
+
+func TestParseLogLevel(t *testing.T) {
+	for name, want := range map[string]slog.Level{"debug": slog.LevelDebug, "INFO": slog.LevelInfo, "warn": slog.LevelWarn} {
+		if got, err := ParseLogLevel(name); err != nil || got != want {
+			t.Fatalf("ParseLogLevel(%q) = %v, %v, want %v", name, got, err, want)
+		}
+	}
+	if _, err := ParseLogLevel("loud"); err == nil {
+		t.Fatalf("ParseLogLevel(%q) succeeded, want an error", "loud")
+	}
+}
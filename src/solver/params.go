@@ -0,0 +1,111 @@
+package solver
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Params holds named parameters for solvers, like the size of a map that
+// differs between the example and the real input. Values are kept as text
+// and converted by the accessors of `Options`.
+type Params map[string]string
+
+// ParamsFrom returns the parameters stored in `ctx` under "params".
+func ParamsFrom(ctx context.Context) Params {
+	params, _ := ctx.Value("params").(Params)
+	return params
+}
+
+// ParseParams reads parameters given as "name=value" pairs, like those of the
+// --param flag. Later pairs override earlier ones.
+func ParseParams(pairs []string) (Params, error) {
+	params := Params{}
+	for _, pair := range pairs {
+		name, value, ok := strings.Cut(pair, "=")
+		name = strings.TrimSpace(name)
+		if !ok || name == "" {
+			return nil, fmt.Errorf("invalid parameter %q, expected name=value", pair)
+		}
+		params[name] = strings.TrimSpace(value)
+	}
+	return params, nil
+}
+
+// Merge returns a copy of `p` with the values of `other` added, overriding
+// those with the same name.
+func (p Params) Merge(other Params) Params {
+	merged := maps.Clone(p)
+	if merged == nil {
+		merged = Params{}
+	}
+	maps.Copy(merged, other)
+	return merged
+}
+
+// Names returns the parameter names in alphabetical order.
+func (p Params) Names() []string {
+	return slices.Sorted(maps.Keys(p))
+}
+
+// LookupParam returns the text of parameter `name`, if it is set.
+func (opts Options) LookupParam(name string) (string, bool) {
+	value, ok := opts.params[name]
+	return value, ok
+}
+
+// Param returns parameter `name`, or `fallback` when it is not set.
+func (opts Options) Param(name string, fallback string) string {
+	if value, ok := opts.LookupParam(name); ok {
+		return value
+	}
+	return fallback
+}
+
+// IntParam returns parameter `name` as an integer, or `fallback` when it is not
+// set. Values that are not integers are logged and replaced by `fallback`.
+func (opts Options) IntParam(name string, fallback int) int {
+	value, ok := opts.LookupParam(name)
+	if !ok {
+		return fallback
+	}
+
+	return parseParam(opts, name, value, fallback, strconv.Atoi)
+}
+
+// FloatParam returns parameter `name` as a floating point number, or `fallback`
+// when it is not set. Values that are not numbers are logged and replaced by
+// `fallback`.
+func (opts Options) FloatParam(name string, fallback float64) float64 {
+	value, ok := opts.LookupParam(name)
+	if !ok {
+		return fallback
+	}
+
+	return parseParam(opts, name, value, fallback, func(text string) (float64, error) {
+		return strconv.ParseFloat(text, 64)
+	})
+}
+
+// BoolParam returns parameter `name` as a boolean, or `fallback` when it is not
+// set. Values that are not booleans are logged and replaced by `fallback`.
+func (opts Options) BoolParam(name string, fallback bool) bool {
+	value, ok := opts.LookupParam(name)
+	if !ok {
+		return fallback
+	}
+
+	return parseParam(opts, name, value, fallback, strconv.ParseBool)
+}
+
+func parseParam[T any](opts Options, name string, value string, fallback T, parse func(string) (T, error)) T {
+	parsed, err := parse(value)
+	if err != nil {
+		opts.Logger().Warn("ignoring parameter", "name", name, "value", value, "error", err)
+		return fallback
+	}
+	return parsed
+}
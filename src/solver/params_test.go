@@ -0,0 +1,51 @@
+package solver
+
+import (
+	"maps"
+	"testing"
+)
+
+func TestOptionsParams(t *testing.T) {
+	opts := Options{discardLogger, Params{"width": "11", "name": "example", "height": "seven"}}
+
+	if got := opts.IntParam("width", 101); got != 11 {
+		t.Fatalf("IntParam(width) = %v, want %v", got, 11)
+	}
+	if got := opts.IntParam("height", 103); got != 103 {
+		t.Fatalf("IntParam(height) = %v, want the fallback %v", got, 103)
+	}
+	if got := opts.IntParam("depth", 5); got != 5 {
+		t.Fatalf("IntParam(depth) = %v, want the fallback %v", got, 5)
+	}
+	if got := opts.Param("name", "input"); got != "example" {
+		t.Fatalf("Param(name) = %v, want %v", got, "example")
+	}
+	if got := DefaultOptions().IntParam("width", 101); got != 101 {
+		t.Fatalf("DefaultOptions().IntParam(width) = %v, want %v", got, 101)
+	}
+}
+
+func TestParseParams(t *testing.T) {
+	params, err := ParseParams([]string{"width=11", "height = 7", "saving=100", "saving=50", "name="})
+	want := Params{"width": "11", "height": "7", "saving": "50", "name": ""}
+	if err != nil || !maps.Equal(params, want) {
+		t.Fatalf("ParseParams() = %v, %v, want %v", params, err, want)
+	}
+
+	for _, pair := range []string{"width", "=11"} {
+		if _, err := ParseParams([]string{pair}); err == nil {
+			t.Fatalf("ParseParams(%q) succeeded, want an error", pair)
+		}
+	}
+
+	opts := Options{discardLogger, Params{"ratio": "0.5", "fast": "true", "slow": "maybe"}}
+	if got := opts.FloatParam("ratio", 1); got != 0.5 {
+		t.Fatalf("FloatParam(ratio) = %v, want %v", got, 0.5)
+	}
+	if got := opts.BoolParam("fast", false); !got {
+		t.Fatalf("BoolParam(fast) = %v, want %v", got, true)
+	}
+	if got := opts.BoolParam("slow", false); got {
+		t.Fatalf("BoolParam(slow) = %v, want the fallback %v", got, false)
+	}
+}
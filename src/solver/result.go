@@ -1,33 +1,134 @@
 package solver
 
 import (
-    "fmt"
-    "time"
+	"fmt"
+	"io"
+	"text/tabwriter"
+	"time"
 )
 
-type Result struct{
-    Name string
-    Part1 string
-    Part2 string
-    Elapsed []time.Duration
+// Status describes how a part of a puzzle fared.
+type Status string
+
+const (
+	StatusSolved         Status = "solved"
+	StatusUnsolved       Status = "unsolved"
+	StatusNotImplemented Status = "not implemented"
+	StatusTimedOut       Status = "timed out"
+)
+
+type Result struct {
+	Name     string
+	Part1    string
+	Part2    string
+	Elapsed  []time.Duration
+	Statuses []Status
+	Errors   []error
+	Answers  []Answer
+}
+
+// FailedResult creates a `Result` for a day that could not be solved at all,
+// e.g. because its input is missing.
+func FailedResult(day string, err error) Result {
+	return Result{
+		Name:     day,
+		Part1:    Unsolved,
+		Part2:    Unsolved,
+		Elapsed:  nil,
+		Statuses: []Status{StatusUnsolved, StatusUnsolved},
+		Errors:   []error{err, err},
+		Answers:  []Answer{NoAnswer, NoAnswer},
+	}
+}
+
+// Answer returns the answer of `part` (1 or 2).
+func (r Result) Answer(part int) string {
+	if part == 2 {
+		return r.Part2
+	}
+	return r.Part1
+}
+
+// TypedAnswer returns the typed answer of `part` (1 or 2). Results without
+// typed answers fall back to `TextAnswer`.
+func (r Result) TypedAnswer(part int) Answer {
+	if part < 1 || part > len(r.Answers) {
+		return TextAnswer(r.Answer(part))
+	}
+	return r.Answers[part-1]
+}
+
+// Status returns the status of `part` (1 or 2).
+func (r Result) Status(part int) Status {
+	if part < 1 || part > len(r.Statuses) {
+		return StatusUnsolved
+	}
+	return r.Statuses[part-1]
+}
+
+// Err returns the error of `part` (1 or 2), if any.
+func (r Result) Err(part int) error {
+	if part < 1 || part > len(r.Errors) {
+		return nil
+	}
+	return r.Errors[part-1]
+}
+
+// Duration returns the elapsed time of `part` (1 or 2), or 0 when it was not
+// measured.
+func (r Result) Duration(part int) time.Duration {
+	if part < 1 || part > len(r.Elapsed) {
+		return 0
+	}
+	return r.Elapsed[part-1]
 }
 
 func (r Result) String() string {
-    if r.Part1 == "" {
-        r.Part1 = Unsolved
-    }
+	if r.Part1 == "" {
+		r.Part1 = Unsolved
+	}
+
+	if r.Part2 == "" {
+		r.Part2 = Unsolved
+	}
+
+	if r.Name == "" {
+		r.Name = Unknown
+	}
+
+	if r.Elapsed != nil && len(r.Elapsed) == 2 {
+		return fmt.Sprintf("%v\t%v\t%v\t%v\t%v", r.Name, r.Part1, r.Part2, r.Elapsed[0], r.Elapsed[1])
+	}
+
+	return fmt.Sprintf("%v\t%v\t%v", r.Name, r.Part1, r.Part2)
+}
+
+// WriteTable writes `results` as an aligned table with a header row.
+func WriteTable(w io.Writer, results []Result) error {
+	tw := tabwriter.NewWriter(w, 0, 8, 2, ' ', 0)
 
-    if r.Part2 == "" {
-        r.Part2 = Unsolved
-    }
+	elapsed := false
+	for _, r := range results {
+		if len(r.Elapsed) == 2 {
+			elapsed = true
+			break
+		}
+	}
 
-    if r.Name == "" {
-        r.Name = Unknown
-    }
+	if elapsed {
+		fmt.Fprintln(tw, "day\tpart1\tpart2\telapsed1\telapsed2")
+	} else {
+		fmt.Fprintln(tw, "day\tpart1\tpart2")
+	}
 
-    if r.Elapsed != nil && len(r.Elapsed) == 2 {
-        return fmt.Sprintf("%v\t%v\t%v\t%v\t%v", r.Name, r.Part1, r.Part2, r.Elapsed[0], r.Elapsed[1])
-    }
+	for _, r := range results {
+		if elapsed && len(r.Elapsed) != 2 {
+			r.Elapsed = nil
+			fmt.Fprintf(tw, "%v\t-\t-\n", r)
+			continue
+		}
+		fmt.Fprintln(tw, r)
+	}
 
-    return fmt.Sprintf("%v\t%v\t%v", r.Name, r.Part1, r.Part2)
+	return tw.Flush()
 }
@@ -0,0 +1,56 @@
+package solver
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWriteTable(t *testing.T) {
+	solved := Result{Name: "2", Part1: "11", Part2: "22", Elapsed: []time.Duration{time.Millisecond, 2 * time.Millisecond}}
+	unmeasured := Result{Name: "10", Part1: "1", Part2: Unsolved}
+
+	for _, tc := range []struct {
+		name    string
+		results []Result
+		want    []string
+	}{
+		{
+			"without elapsed",
+			[]Result{unmeasured},
+			[]string{
+				"day  part1  part2",
+				"10   1      unsolved",
+			},
+		},
+		{
+			"with elapsed",
+			[]Result{solved},
+			[]string{
+				"day  part1  part2  elapsed1  elapsed2",
+				"2    11     22     1ms       2ms",
+			},
+		},
+		{
+			"mixed",
+			[]Result{solved, unmeasured},
+			[]string{
+				"day  part1  part2     elapsed1  elapsed2",
+				"2    11     22        1ms       2ms",
+				"10   1      unsolved  -         -",
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var sb strings.Builder
+			if err := WriteTable(&sb, tc.results); err != nil {
+				t.Fatal(err)
+			}
+
+			want := strings.Join(tc.want, "\n") + "\n"
+			if sb.String() != want {
+				t.Fatalf("WriteTable() =\n%s\nwant\n%s", sb.String(), want)
+			}
+		})
+	}
+}
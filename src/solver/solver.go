@@ -1,165 +1,405 @@
 package solver
 
 import (
-    "errors"
-    "fmt"
-    "io"
-    "context"
-    "time"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
 )
 
-
 const (
-    Unknown = "unknown"
-    Unsolved = "unsolved"
-    Undefined = "undefined"
-    InProgress = "in progress"
+	Unknown    = "unknown"
+	Unsolved   = "unsolved"
+	Undefined  = "undefined"
+	InProgress = "in progress"
 )
 
 var (
-    ErrNotImplemented = errors.New("Not implemented")
+	ErrNotImplemented = errors.New("Not implemented")
 )
 
 func NotImplemented() (string, error) {
-    return Unsolved, ErrNotImplemented
+	return Unsolved, ErrNotImplemented
 }
 
 func Solved[T any](value T) (string, error) {
-    return fmt.Sprintf("%v", value), nil
+	return fmt.Sprintf("%v", value), nil
 }
 
 func Error(err error) (string, error) {
-    return Unsolved, err
+	return Unsolved, err
 }
 
-
 type Day int
 
+// DefaultYear is the year of the puzzles of solvers that do not implement
+// `YearSolver`.
+const DefaultYear = "2024"
+
+// YearSolver can be implemented by solvers of puzzles of other years than
+// `DefaultYear`.
+type YearSolver interface {
+	Solver
+	Year() string
+}
+
+// YearOf returns the year of the puzzle solved by `s`.
+func YearOf(s Solver) string {
+	if ys, ok := s.(YearSolver); ok && ys.Year() != "" {
+		return ys.Year()
+	}
+	return DefaultYear
+}
+
 type Options struct {
-    debug bool
+	logger *slog.Logger
+	params Params
 }
 
 func DefaultOptions() Options {
-    return Options{false}
+	return Options{discardLogger, nil}
 }
 
+// Debugf logs a formatted message at debug level. A trailing newline is
+// dropped, as every message is a line of its own.
 func (opts Options) Debugf(format string, values ...any) {
-    if opts.debug {
-        fmt.Printf(format, values...)
-    }
+	if opts.IsDebug() {
+		opts.Logger().Debug(strings.TrimSuffix(fmt.Sprintf(format, values...), "\n"))
+	}
+}
+
+// Debug logs `msg` at debug level with structured fields given as key-value
+// pairs or `slog.Attr`s.
+func (opts Options) Debug(msg string, args ...any) {
+	opts.Logger().Debug(msg, args...)
 }
 
-func (opts Options) IfDebugDo(printer func(opts Options)) {
-    if opts.debug {
-        printer(opts)
-    }
+// With returns options whose log messages carry the given fields.
+func (opts Options) With(args ...any) Options {
+	return Options{opts.Logger().With(args...), opts.params}
+}
+
+// IfDebugDo calls `printer` with the writer of the logger when debug messages
+// are enabled, for output that does not fit a log line, like a grid.
+func (opts Options) IfDebugDo(printer func(w io.Writer)) {
+	if opts.IsDebug() {
+		printer(LogWriter(opts.Logger()))
+	}
 }
 
 func (opts Options) IsDebug() bool {
-    return opts.debug
+	return opts.Logger().Enabled(context.Background(), slog.LevelDebug)
+}
+
+// Logger returns the logger the options write to, which discards everything
+// unless one was provided by `Solve`.
+func (opts Options) Logger() *slog.Logger {
+	if opts.logger == nil {
+		return discardLogger
+	}
+	return opts.logger
+}
+
+type Solver interface {
+	Part1(input []string, opts Options) (string, error)
+	Part2(input []string, opts Options) (string, error)
+	Day() string
+}
+
+// ContextSolver can optionally be implemented by solvers whose parts can be
+// cancelled. Its `Part1Context` and `Part2Context` are used instead of `Part1`
+// and `Part2` and should return `ctx.Err()` once `ctx` is done.
+type ContextSolver interface {
+	Solver
+	Part1Context(ctx context.Context, input []string, opts Options) (string, error)
+	Part2Context(ctx context.Context, input []string, opts Options) (string, error)
 }
 
-type Solver interface{
-    Part1(input []string, opts Options) (string, error)
-    Part2(input []string, opts Options) (string, error)
-    Day() string
+// TypedSolver can optionally be implemented by solvers that return typed
+// answers. Its `Part1Typed` and `Part2Typed` are used instead of any other
+// variant and should return `ctx.Err()` once `ctx` is done. The plain parts
+// can be adapted with `Text`.
+type TypedSolver interface {
+	Solver
+	Part1Typed(ctx context.Context, input []string, opts Options) (Answer, error)
+	Part2Typed(ctx context.Context, input []string, opts Options) (Answer, error)
+}
+
+type partFunc func(ctx context.Context, input []string, opts Options) (Answer, error)
+
+// SequentialSolver can be implemented by solvers that share state between
+// their parts, e.g. through package-level variables. When `Sequential` returns
+// true, the parts are never run concurrently.
+type SequentialSolver interface {
+	Solver
+	Sequential() bool
+}
+
+// IsSequential tells whether the parts of `s` must run one after the other.
+func IsSequential(s Solver) bool {
+	seq, ok := s.(SequentialSolver)
+	return ok && seq.Sequential()
+}
+
+// partFuncs returns both parts of `s`, preferring the typed variants of a
+// `TypedSolver` and then the context aware variants of a `ContextSolver`.
+// Text answers are converted with `TextAnswer`.
+func partFuncs(s Solver) []partFunc {
+	if ts, ok := s.(TypedSolver); ok {
+		return []partFunc{ts.Part1Typed, ts.Part2Typed}
+	}
+
+	typed := func(part func(context.Context, []string, Options) (string, error)) partFunc {
+		return func(ctx context.Context, input []string, opts Options) (Answer, error) {
+			text, err := part(ctx, input, opts)
+			return TextAnswer(text), err
+		}
+	}
+
+	if cs, ok := s.(ContextSolver); ok {
+		return []partFunc{typed(cs.Part1Context), typed(cs.Part2Context)}
+	}
+
+	ignoreCtx := func(part func([]string, Options) (string, error)) partFunc {
+		return typed(func(_ context.Context, input []string, opts Options) (string, error) {
+			return part(input, opts)
+		})
+	}
+
+	return []partFunc{ignoreCtx(s.Part1), ignoreCtx(s.Part2)}
+}
+
+// runPart runs a part, giving up after `timeout` when it is positive. Parts of
+// solvers that do not implement `ContextSolver` cannot be stopped, so they
+// are left running in the background when they time out; `running` tells
+// when they are done.
+func runPart(ctx context.Context, part partFunc, input []string, opts Options, timeout time.Duration, running *sync.WaitGroup) (Answer, error) {
+	if timeout <= 0 {
+		return part(ctx, input, opts)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	type outcome struct {
+		answer Answer
+		err    error
+	}
+
+	done := make(chan outcome, 1)
+	running.Add(1)
+	go func() {
+		defer running.Done()
+		answer, err := part(ctx, input, opts)
+		done <- outcome{answer, err}
+	}()
+
+	select {
+	case out := <-done:
+		return out.answer, out.err
+	case <-ctx.Done():
+		return NoAnswer, ctx.Err()
+	}
+}
+
+type puzzleKey struct {
+	year string
+	day  string
 }
 
 var (
-    solvers = make(map[string]Solver)
+	solvers = make(map[puzzleKey]Solver)
 )
 
-
 func Register(solver Solver) {
-    if solver == nil {
-        panic("puzzle: Register solver is nil")
-    }
+	if solver == nil {
+		panic("puzzle: Register solver is nil")
+	}
 
-    name := solver.Day()
+	key := puzzleKey{YearOf(solver), solver.Day()}
 
-    if _, dup := solvers[name]; dup {
-        panic(fmt.Errorf("puzzle: Register called twice for solver [%s]", name))
-    }
+	if _, dup := solvers[key]; dup {
+		panic(fmt.Errorf("puzzle: Register called twice for solver [%s/%s]", key.year, key.day))
+	}
 
-    solvers[name] = solver
+	solvers[key] = solver
 }
 
-func GetSolver(day string) (Solver, error) {
-    if day == "" {
-        return nil, errors.New("empty puzzle day")
-    }
+// GetSolver returns the solver of `day` in `year`, where an empty year means
+// `DefaultYear`.
+func GetSolver(year string, day string) (Solver, error) {
+	if day == "" {
+		return nil, errors.New("empty puzzle day")
+	}
+
+	if year == "" {
+		year = DefaultYear
+	}
+
+	solver, exist := solvers[puzzleKey{year, day}]
+	if !exist {
+		if year != DefaultYear {
+			day = year + "/" + day
+		}
+		return nil, fmt.Errorf("%s: %w", day, errors.New("unknown puzzle day"))
+	}
+
+	return solver, nil
+}
+
+// Solvers returns all registered solvers, ordered by year and day.
+func Solvers() []Solver {
+	all := make([]Solver, 0, len(solvers))
+	for _, solver := range solvers {
+		all = append(all, solver)
+	}
+
+	sort.Slice(all, func(i, j int) bool {
+		yi, yj := YearOf(all[i]), YearOf(all[j])
+		if yi != yj {
+			return yi < yj
+		}
+		return dayOrder(all[i].Day()) < dayOrder(all[j].Day())
+	})
+
+	return all
+}
 
-    solver, exist := solvers[day]
-    if !exist {
-        return nil, fmt.Errorf("%s: %w", day, errors.New("unknown puzzle day"))
-    }
+// SolversOf returns the registered solvers of `year`, ordered by day.
+func SolversOf(year string) []Solver {
+	of := []Solver{}
+	for _, solver := range Solvers() {
+		if YearOf(solver) == year {
+			of = append(of, solver)
+		}
+	}
+	return of
+}
 
-    return solver, nil
+func dayOrder(day string) int {
+	n, err := strconv.Atoi(day)
+	if err != nil {
+		return math.MaxInt
+	}
+	return n
 }
 
 func Solve(solver Solver, input io.Reader, ctx context.Context) (Result, error) {
-    res := Result{
-        Name: solver.Day(),
-        Part1: Unsolved,
-        Part2: Unsolved,
-        Elapsed: nil,
-    }
+	res := Result{
+		Name:    solver.Day(),
+		Part1:   Unsolved,
+		Part2:   Unsolved,
+		Elapsed: nil,
+	}
 
-    lines, err := ReadLines(input)
+	lines, err := ReadLines(input)
 
-    if err != nil {
-        return Result{}, fmt.Errorf("failed to read: %w", err)
-    }
+	if err != nil {
+		return Result{}, fmt.Errorf("failed to read: %w", err)
+	}
 
-    if err := res.AddAnswers(solver, lines, ctx); err != nil {
-        return Result{}, fmt.Errorf("failed to add answers: %w", err)
-    }
+	if err := res.AddAnswers(solver, lines, ctx); err != nil {
+		return res, fmt.Errorf("failed to add answers: %w", err)
+	}
 
-    return res, nil
+	return res, nil
 }
 
 func (r *Result) AddAnswers(s Solver, input []string, ctx context.Context) error {
-    elapsed, ok := ctx.Value("elapsed").(bool)
-    if !ok {
-        elapsed = false
-    }
-
-    durations := []time.Duration{}
-
-    debug, dok := ctx.Value("debug").(bool)
-    opts := Options{dok && debug}
-
-    var start time.Time
-
-    if (elapsed) {
-        start = time.Now()
-    }
-    part1, err := s.Part1(input, opts)
-    if (elapsed) {
-        durations = append(durations, time.Since(start))
-    }
-    if err != nil && !errors.Is(err, ErrNotImplemented) {
-        return fmt.Errorf("failed to solve Part1: %w", err)
-    }
-
-    if (elapsed) {
-        start = time.Now()
-    }
-    part2, err := s.Part2(input, opts)
-    if (elapsed) {
-        durations = append(durations, time.Since(start))
-    }
-    if err != nil && !errors.Is(err, ErrNotImplemented) {
-        return fmt.Errorf("failed to solve Part2: %w", err)
-    }
-
-    if !elapsed {
-        durations = nil
-    }
-
-    r.Part1 = part1
-    r.Part2 = part2
-    r.Elapsed = durations
-
-    return nil
+	elapsed, ok := ctx.Value("elapsed").(bool)
+	if !ok {
+		elapsed = false
+	}
+
+	logger := LoggerFrom(ctx).With("day", s.Day())
+	params := ParamsFrom(ctx)
+
+	timeout, _ := ctx.Value("timeout").(time.Duration)
+
+	sequential := IsSequential(s)
+	parallel, pok := ctx.Value("parallel").(bool)
+	parallel = pok && parallel && !sequential
+
+	parts := partFuncs(s)
+
+	answers := make([]Answer, len(parts))
+	durations := make([]time.Duration, len(parts))
+	statuses := make([]Status, len(parts))
+	errs := make([]error, len(parts))
+	failed := make([]error, len(parts))
+
+	var running sync.WaitGroup
+
+	solvePart := func(idx int) {
+		opts := Options{logger.With("part", idx+1), params}
+
+		start := time.Now()
+		answer, err := runPart(ctx, parts[idx], input, opts, timeout, &running)
+		durations[idx] = time.Since(start)
+
+		status := StatusSolved
+		switch {
+		case errors.Is(err, ErrNotImplemented):
+			status = StatusNotImplemented
+			err = nil
+		case errors.Is(err, context.DeadlineExceeded):
+			status = StatusTimedOut
+			answer = NoAnswer
+			failed[idx] = fmt.Errorf("Part%d timed out after %v: %w", idx+1, timeout, err)
+		case err != nil:
+			status = StatusUnsolved
+			failed[idx] = fmt.Errorf("failed to solve Part%d: %w", idx+1, err)
+		}
+
+		answers[idx] = answer
+		statuses[idx] = status
+		errs[idx] = err
+
+		opts.Logger().Info("part finished", "status", status, "elapsed", durations[idx])
+	}
+
+	if parallel {
+		var wg sync.WaitGroup
+		for idx := range parts {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				solvePart(idx)
+			}()
+		}
+		wg.Wait()
+	} else {
+		for idx := range parts {
+			solvePart(idx)
+
+			// a part that timed out may still be running, which the next part
+			// of a sequential solver must not overlap with
+			if sequential {
+				if statuses[idx] == StatusTimedOut {
+					logger.Warn("waiting for timed out part of sequential solver", "part", idx+1)
+				}
+				running.Wait()
+			}
+		}
+	}
+
+	if !elapsed {
+		durations = nil
+	}
+
+	r.Part1 = answers[0].String()
+	r.Part2 = answers[1].String()
+	r.Answers = answers
+	r.Elapsed = durations
+	r.Statuses = statuses
+	r.Errors = errs
+
+	return errors.Join(failed...)
 }
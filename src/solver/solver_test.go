@@ -0,0 +1,258 @@
+package solver
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+type slowSolver struct{}
+
+func (s slowSolver) Day() string { return "slow" }
+
+func (s slowSolver) Part1(input []string, opts Options) (string, error) {
+	return Solved(len(input))
+}
+
+func (s slowSolver) Part2(input []string, opts Options) (string, error) {
+	time.Sleep(time.Second)
+	return Solved(0)
+}
+
+type stoppableSolver struct {
+	slowSolver
+}
+
+func (s stoppableSolver) Part1Context(_ context.Context, input []string, opts Options) (string, error) {
+	return s.Part1(input, opts)
+}
+
+func (s stoppableSolver) Part2Context(ctx context.Context, input []string, opts Options) (string, error) {
+	<-ctx.Done()
+	return Error(ctx.Err())
+}
+
+func TestAddAnswersTimeout(t *testing.T) {
+	solvers := []Solver{slowSolver{}, stoppableSolver{}}
+
+	for _, s := range solvers {
+		ctx := context.WithValue(context.Background(), "timeout", 10*time.Millisecond)
+
+		res := Result{Name: s.Day()}
+		err := res.AddAnswers(s, []string{"a", "b"}, ctx)
+
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Fatalf("AddAnswers(%T) = %v, want %v", s, err, context.DeadlineExceeded)
+		}
+		if res.Part1 != "2" || res.Status(1) != StatusSolved {
+			t.Fatalf("AddAnswers(%T) part 1 = %v, %v, want %v, %v", s, res.Part1, res.Status(1), "2", StatusSolved)
+		}
+		if res.Part2 != Unsolved || res.Status(2) != StatusTimedOut {
+			t.Fatalf("AddAnswers(%T) part 2 = %v, %v, want %v, %v", s, res.Part2, res.Status(2), Unsolved, StatusTimedOut)
+		}
+	}
+}
+
+type brokenSolver struct {
+	slowSolver
+}
+
+func (s brokenSolver) Part1(input []string, opts Options) (string, error) {
+	return Error(errors.New("broken"))
+}
+
+func (s brokenSolver) Part2(input []string, opts Options) (string, error) {
+	return Solved(len(input))
+}
+
+func TestAddAnswersAfterFailedPart(t *testing.T) {
+	res := Result{Name: "broken"}
+	err := res.AddAnswers(brokenSolver{}, []string{"a", "b"}, context.Background())
+
+	if err == nil || res.Status(1) != StatusUnsolved {
+		t.Fatalf("AddAnswers() part 1 = %v, %v, want %v and an error", res.Status(1), err, StatusUnsolved)
+	}
+	if res.Part2 != "2" || res.Status(2) != StatusSolved {
+		t.Fatalf("AddAnswers() part 2 = %v, %v, want %v, %v", res.Part2, res.Status(2), "2", StatusSolved)
+	}
+}
+
+// sharedSolver changes the same map in both parts, the first one taking
+// longer than the timeout of the tests.
+type sharedSolver struct {
+	shared map[int]bool
+}
+
+func (s sharedSolver) Day() string { return "shared" }
+
+func (s sharedSolver) Sequential() bool { return true }
+
+func (s sharedSolver) Part1(input []string, opts Options) (string, error) {
+	time.Sleep(50 * time.Millisecond)
+	s.shared[1] = true
+	return Solved(len(s.shared))
+}
+
+func (s sharedSolver) Part2(input []string, opts Options) (string, error) {
+	s.shared[2] = true
+	return Solved(len(s.shared))
+}
+
+func TestAddAnswersTimeoutSequential(t *testing.T) {
+	ctx := context.WithValue(context.Background(), "timeout", 10*time.Millisecond)
+
+	res := Result{Name: "shared"}
+	err := res.AddAnswers(sharedSolver{map[int]bool{}}, nil, ctx)
+
+	if !errors.Is(err, context.DeadlineExceeded) || res.Status(1) != StatusTimedOut {
+		t.Fatalf("AddAnswers() part 1 = %v, %v, want %v", res.Status(1), err, StatusTimedOut)
+	}
+	if res.Part2 != "2" {
+		t.Fatalf("AddAnswers() part 2 = %v, want %v as part 1 finished before it started", res.Part2, 2)
+	}
+}
+
+// rendezvousSolver only finishes when both parts run at the same time.
+type rendezvousSolver struct {
+	started chan bool
+}
+
+func (s rendezvousSolver) Day() string { return "rendezvous" }
+
+func (s rendezvousSolver) Part1(input []string, opts Options) (string, error) {
+	return s.meet()
+}
+
+func (s rendezvousSolver) Part2(input []string, opts Options) (string, error) {
+	return s.meet()
+}
+
+func (s rendezvousSolver) meet() (string, error) {
+	select {
+	case s.started <- true:
+		return Solved("first")
+	case <-s.started:
+		return Solved("second")
+	case <-time.After(100 * time.Millisecond):
+		return Solved("alone")
+	}
+}
+
+type sequentialRendezvousSolver struct {
+	rendezvousSolver
+}
+
+func (s sequentialRendezvousSolver) Sequential() bool { return true }
+
+func TestAddAnswersParallel(t *testing.T) {
+	ctx := context.WithValue(context.Background(), "parallel", true)
+
+	res := Result{}
+	if err := res.AddAnswers(rendezvousSolver{make(chan bool)}, nil, ctx); err != nil {
+		t.Fatal(err)
+	}
+	if res.Part1 == "alone" || res.Part2 == "alone" {
+		t.Fatalf("parts did not run concurrently: %v, %v", res.Part1, res.Part2)
+	}
+
+	res = Result{}
+	if err := res.AddAnswers(sequentialRendezvousSolver{rendezvousSolver{make(chan bool)}}, nil, ctx); err != nil {
+		t.Fatal(err)
+	}
+	if res.Part1 != "alone" || res.Part2 != "alone" {
+		t.Fatalf("sequential parts ran concurrently: %v, %v", res.Part1, res.Part2)
+	}
+}
+
+type oldSolver struct {
+	slowSolver
+}
+
+func (s oldSolver) Year() string { return "2015" }
+
+func (s oldSolver) Day() string { return "99" }
+
+// emptyRegistry empties the registered solvers for the duration of the test.
+func emptyRegistry(t *testing.T) {
+	t.Helper()
+
+	registered := solvers
+	solvers = map[puzzleKey]Solver{}
+	t.Cleanup(func() { solvers = registered })
+}
+
+func TestRegisterYears(t *testing.T) {
+	emptyRegistry(t)
+	Register(oldSolver{})
+	Register(datedSolver{year: DefaultYear, day: "1"})
+
+	if s, err := GetSolver("2015", "99"); err != nil || s != (oldSolver{}) {
+		t.Fatalf("GetSolver(2015, 99) = %v, %v, want %v", s, err, oldSolver{})
+	}
+	if _, err := GetSolver("", "99"); err == nil {
+		t.Fatalf("GetSolver(\"\", 99) succeeded, want an error as day 99 is only registered for 2015")
+	}
+	if of := SolversOf("2015"); len(of) != 1 || YearOf(of[0]) != "2015" {
+		t.Fatalf("SolversOf(2015) = %v, want [%v]", of, oldSolver{})
+	}
+	if of := SolversOf(DefaultYear); len(of) != 1 {
+		t.Fatalf("SolversOf(%v) = %v, want 1 solver", DefaultYear, of)
+	}
+	for _, s := range SolversOf(DefaultYear) {
+		if YearOf(s) != DefaultYear {
+			t.Fatalf("SolversOf(%v) contains %v of %v", DefaultYear, s, YearOf(s))
+		}
+	}
+}
+
+type datedSolver struct {
+	slowSolver
+	year string
+	day  string
+}
+
+func (s datedSolver) Year() string { return s.year }
+
+func (s datedSolver) Day() string { return s.day }
+
+func TestSolversOrder(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		solvers []datedSolver
+		want    []string
+	}{
+		{
+			"numeric days",
+			[]datedSolver{{year: "2024", day: "10"}, {year: "2024", day: "2"}, {year: "2024", day: "1"}},
+			[]string{"2024/1", "2024/2", "2024/10"},
+		},
+		{
+			"years first",
+			[]datedSolver{{year: "2024", day: "2"}, {year: "2015", day: "10"}, {year: "2016", day: "1"}, {year: "2015", day: "2"}},
+			[]string{"2015/2", "2015/10", "2016/1", "2024/2"},
+		},
+		{
+			"other days last",
+			[]datedSolver{{year: "2024", day: "bonus"}, {year: "2024", day: "25"}},
+			[]string{"2024/25", "2024/bonus"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			emptyRegistry(t)
+
+			for _, s := range tc.solvers {
+				Register(s)
+			}
+
+			got := []string{}
+			for _, s := range Solvers() {
+				got = append(got, YearOf(s)+"/"+s.Day())
+			}
+			if strings.Join(got, " ") != strings.Join(tc.want, " ") {
+				t.Fatalf("Solvers() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,274 @@
+package solver
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	// ErrKnownWrong returns when an answer is refused because an earlier
+	// submission already showed it to be wrong.
+	ErrKnownWrong = errors.New("answer is known to be wrong")
+
+	reArticle = regexp.MustCompile(`(?s)<article[^>]*>(.*?)</article>`)
+	reTag     = regexp.MustCompile(`<[^>]*>`)
+	reSpace   = regexp.MustCompile(`\s+`)
+	reWait    = regexp.MustCompile(`You have (?:(\d+)m )?(\d+)s left to wait`)
+)
+
+// Outcome is the verdict of the puzzle site on a submitted answer.
+type Outcome int
+
+const (
+	OutcomeUnknown Outcome = iota
+	OutcomeCorrect
+	OutcomeWrong
+	OutcomeTooHigh
+	OutcomeTooLow
+	OutcomeRateLimited
+	OutcomeAlreadySolved
+)
+
+var outcomeNames = map[Outcome]string{
+	OutcomeUnknown:       "unknown",
+	OutcomeCorrect:       "correct",
+	OutcomeWrong:         "wrong",
+	OutcomeTooHigh:       "too high",
+	OutcomeTooLow:        "too low",
+	OutcomeRateLimited:   "rate limited",
+	OutcomeAlreadySolved: "already solved",
+}
+
+func (o Outcome) String() string {
+	name, ok := outcomeNames[o]
+	if !ok {
+		return Unknown
+	}
+	return name
+}
+
+func (o Outcome) MarshalText() ([]byte, error) {
+	return []byte(o.String()), nil
+}
+
+func (o *Outcome) UnmarshalText(text []byte) error {
+	for outcome, name := range outcomeNames {
+		if name == string(text) {
+			*o = outcome
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown outcome %q", text)
+}
+
+// IsWrong tells whether the answer was rejected as incorrect.
+func (o Outcome) IsWrong() bool {
+	return o == OutcomeWrong || o == OutcomeTooHigh || o == OutcomeTooLow
+}
+
+// Submission records an answer submitted for a puzzle part and the site's
+// response.
+type Submission struct {
+	Day     string        `json:"day"`
+	Part    int           `json:"part"`
+	Answer  string        `json:"answer"`
+	Outcome Outcome       `json:"outcome"`
+	Wait    time.Duration `json:"wait,omitempty"`
+	Message string        `json:"message,omitempty"`
+	Time    time.Time     `json:"time"`
+}
+
+func (s Submission) String() string {
+	if s.Outcome == OutcomeRateLimited {
+		return fmt.Sprintf("%v\t%v\t%v\t%v (wait %v)", s.Day, s.Part, s.Answer, s.Outcome, s.Wait)
+	}
+	return fmt.Sprintf("%v\t%v\t%v\t%v", s.Day, s.Part, s.Answer, s.Outcome)
+}
+
+// SubmitAnswer posts `answer` for part `part` of day `d` in `year` and parses
+// the verdict from the response.
+func SubmitAnswer(ctx context.Context, year string, d string, part int, answer string, session string) (Submission, error) {
+	sub := Submission{Day: d, Part: part, Answer: answer, Time: time.Now()}
+
+	req, err := createAnswerReq(ctx, year, d, part, answer, session)
+	if err != nil {
+		return sub, fmt.Errorf("create answer request: %w", err)
+	}
+
+	resp, body, err := fetch(ctx, req)
+	if err != nil {
+		return sub, err
+	}
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		sub.Outcome, sub.Wait, sub.Message = ParseOutcome(string(body))
+		return sub, nil
+	case http.StatusNotFound:
+		return sub, fmt.Errorf("[%s]: %w", d, ErrNotFound)
+	case http.StatusBadRequest:
+		return sub, ErrUnauthorized
+	default:
+		return sub, fmt.Errorf("[%s] failed to submit answer[%s]", d, resp.Status)
+	}
+}
+
+// ParseOutcome extracts the verdict, the wait time when rate limited, and the
+// plain text message from the HTML returned after submitting an answer.
+func ParseOutcome(page string) (Outcome, time.Duration, string) {
+	msg := page
+	if caps := reArticle.FindStringSubmatch(page); caps != nil {
+		msg = caps[1]
+	}
+	msg = html.UnescapeString(reTag.ReplaceAllString(msg, ""))
+	msg = strings.TrimSpace(reSpace.ReplaceAllString(msg, " "))
+
+	switch {
+	case strings.Contains(msg, "That's the right answer"):
+		return OutcomeCorrect, 0, msg
+	case strings.Contains(msg, "your answer is too high"):
+		return OutcomeTooHigh, 0, msg
+	case strings.Contains(msg, "your answer is too low"):
+		return OutcomeTooLow, 0, msg
+	case strings.Contains(msg, "That's not the right answer"):
+		return OutcomeWrong, 0, msg
+	case strings.Contains(msg, "You gave an answer too recently"):
+		return OutcomeRateLimited, parseWait(msg), msg
+	case strings.Contains(msg, "Did you already complete it?"):
+		return OutcomeAlreadySolved, 0, msg
+	default:
+		return OutcomeUnknown, 0, msg
+	}
+}
+
+func parseWait(msg string) time.Duration {
+	caps := reWait.FindStringSubmatch(msg)
+	if caps == nil {
+		return 0
+	}
+
+	minutes, _ := strconv.Atoi(caps[1])
+	seconds, _ := strconv.Atoi(caps[2])
+
+	return time.Duration(minutes)*time.Minute + time.Duration(seconds)*time.Second
+}
+
+// createAnswerReq creates an HTTP request for submitting an Advent of Code
+// answer given year/day/part.
+func createAnswerReq(ctx context.Context, year string, d string, part int, answer string, sessionID string) (*http.Request, error) {
+	u, err := puzzleURL(year, "day", d, "answer")
+	if err != nil {
+		return nil, err
+	}
+
+	form := url.Values{}
+	form.Set("level", strconv.Itoa(part))
+	form.Set("answer", answer)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, u, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	authorize(req, sessionID)
+
+	return req, nil
+}
+
+// SubmissionLog keeps the history of submitted answers on disk so that
+// known-wrong answers are not submitted again.
+type SubmissionLog struct {
+	path        string
+	Submissions []Submission
+}
+
+// SubmissionsPath returns the location of the submission history in the
+// cache.
+func (c InputCache) SubmissionsPath() string {
+	return filepath.Join(c.Dir, "submissions.json")
+}
+
+// LoadSubmissionLog reads the submission history at `path`. A missing file
+// results in an empty history.
+func LoadSubmissionLog(path string) (*SubmissionLog, error) {
+	sublog := &SubmissionLog{path, []Submission{}}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return sublog, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read submissions: %w", err)
+	}
+
+	if err := json.Unmarshal(data, &sublog.Submissions); err != nil {
+		return nil, fmt.Errorf("parse submissions %s: %w", path, err)
+	}
+
+	return sublog, nil
+}
+
+// Save writes the submission history back to disk.
+func (l *SubmissionLog) Save() error {
+	data, err := json.MarshalIndent(l.Submissions, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode submissions: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(l.path), 0o755); err != nil {
+		return fmt.Errorf("create submissions dir: %w", err)
+	}
+
+	if err := os.WriteFile(l.path, append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("write submissions: %w", err)
+	}
+	return nil
+}
+
+// Add appends a submission to the history.
+func (l *SubmissionLog) Add(sub Submission) {
+	l.Submissions = append(l.Submissions, sub)
+}
+
+// Check refuses answers that earlier submissions showed to be wrong, either
+// directly or because they fall outside the too high/too low bounds.
+func (l *SubmissionLog) Check(d string, part int, answer string) error {
+	value, numErr := strconv.Atoi(answer)
+
+	for _, sub := range l.Submissions {
+		if sub.Day != d || sub.Part != part || !sub.Outcome.IsWrong() {
+			continue
+		}
+
+		if sub.Answer == answer {
+			return fmt.Errorf("%q: %w", answer, ErrKnownWrong)
+		}
+
+		bound, err := strconv.Atoi(sub.Answer)
+		if numErr != nil || err != nil {
+			continue
+		}
+
+		if sub.Outcome == OutcomeTooHigh && value >= bound {
+			return fmt.Errorf("%q, %v was too high: %w", answer, bound, ErrKnownWrong)
+		}
+		if sub.Outcome == OutcomeTooLow && value <= bound {
+			return fmt.Errorf("%q, %v was too low: %w", answer, bound, ErrKnownWrong)
+		}
+	}
+
+	return nil
+}
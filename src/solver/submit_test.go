@@ -0,0 +1,104 @@
+package solver
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func page(msg string) string {
+	return `<!DOCTYPE html><html><body><main><article><p>` + msg + `</p></article></main></body></html>`
+}
+
+type caseOutcome struct {
+	page    string
+	outcome Outcome
+	wait    time.Duration
+}
+
+func TestParseOutcome(t *testing.T) {
+	cases := []caseOutcome{
+		{page(`That's the right answer! You are <span class="day-success">one gold star</span> closer.`), OutcomeCorrect, 0},
+		{page(`That's not the right answer; your answer is too high. Please wait one minute.`), OutcomeTooHigh, 0},
+		{page(`That's not the right answer; your answer is too low.`), OutcomeTooLow, 0},
+		{page(`That's not the right answer. If you're stuck, make sure you're using the full input data.`), OutcomeWrong, 0},
+		{page(`You gave an answer too recently; you have to wait after submitting an answer before trying again.  You have 37s left to wait.`), OutcomeRateLimited, 37 * time.Second},
+		{page(`You gave an answer too recently. You have 4m 3s left to wait.`), OutcomeRateLimited, 4*time.Minute + 3*time.Second},
+		{page(`You don't seem to be solving the right level.  Did you already complete it?`), OutcomeAlreadySolved, 0},
+		{page(`Something else entirely`), OutcomeUnknown, 0},
+	}
+
+	for _, cs := range cases {
+		outcome, wait, msg := ParseOutcome(cs.page)
+		if outcome != cs.outcome || wait != cs.wait {
+			t.Fatalf("ParseOutcome(%q) = %v, %v, want %v, %v", msg, outcome, wait, cs.outcome, cs.wait)
+		}
+	}
+}
+
+func TestSubmitAnswer(t *testing.T) {
+	fakeSite(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/2024/day/4/answer" {
+			t.Errorf("unexpected request %v %v", r.Method, r.URL.Path)
+		}
+		if cookie, err := r.Cookie("session"); err != nil || cookie.Value != "sess" {
+			t.Errorf("unexpected session cookie %v, %v", cookie, err)
+		}
+		if r.FormValue("level") != "2" || r.FormValue("answer") != "1234" {
+			t.Errorf("unexpected form %v", r.Form)
+		}
+		w.Write([]byte(page(`That's the right answer!`)))
+	})
+
+	sub, err := SubmitAnswer(context.Background(), "2024", "4", 2, "1234", "sess")
+	if err != nil || sub.Outcome != OutcomeCorrect {
+		t.Fatalf("SubmitAnswer() = %v, %v, want %v, %v", sub.Outcome, err, OutcomeCorrect, nil)
+	}
+}
+
+func TestSubmissionLogCheck(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "submissions.json")
+
+	history, err := LoadSubmissionLog(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	history.Add(Submission{Day: "4", Part: 1, Answer: "500", Outcome: OutcomeTooHigh})
+	history.Add(Submission{Day: "4", Part: 1, Answer: "100", Outcome: OutcomeTooLow})
+	history.Add(Submission{Day: "4", Part: 2, Answer: "abc", Outcome: OutcomeWrong})
+	history.Add(Submission{Day: "5", Part: 1, Answer: "42", Outcome: OutcomeRateLimited})
+	if err := history.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	history, err = LoadSubmissionLog(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	refused := []Submission{
+		{Day: "4", Part: 1, Answer: "500"},
+		{Day: "4", Part: 1, Answer: "600"},
+		{Day: "4", Part: 1, Answer: "99"},
+		{Day: "4", Part: 2, Answer: "abc"},
+	}
+	for _, sub := range refused {
+		if err := history.Check(sub.Day, sub.Part, sub.Answer); !errors.Is(err, ErrKnownWrong) {
+			t.Fatalf("Check(%v, %v, %q) = %v, want %v", sub.Day, sub.Part, sub.Answer, err, ErrKnownWrong)
+		}
+	}
+
+	allowed := []Submission{
+		{Day: "4", Part: 1, Answer: "250"},
+		{Day: "4", Part: 2, Answer: "500"},
+		{Day: "5", Part: 1, Answer: "42"},
+	}
+	for _, sub := range allowed {
+		if err := history.Check(sub.Day, sub.Part, sub.Answer); err != nil {
+			t.Fatalf("Check(%v, %v, %q) = %v, want %v", sub.Day, sub.Part, sub.Answer, err, nil)
+		}
+	}
+}